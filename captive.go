@@ -0,0 +1,119 @@
+package injector
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// CaptivePolicy determines how Verify treats a singleton that depends on a
+// shorter lived registration, capturing it for the lifetime of the injector.
+type CaptivePolicy = contracts.CaptivePolicy
+
+const (
+	// CaptiveError reports a singleton depending on a scoped registration as a
+	// CaptiveDependencyError. This is the default policy.
+	CaptiveError CaptivePolicy = contracts.CaptiveError
+
+	// CaptiveStrict reports a singleton depending on a scoped or a transient
+	// registration as a CaptiveDependencyError.
+	CaptiveStrict CaptivePolicy = contracts.CaptiveStrict
+
+	// CaptiveWarning reports a singleton depending on a scoped registration
+	// through [Injector.Warnings] rather than failing Verify.
+	CaptiveWarning CaptivePolicy = contracts.CaptiveWarning
+)
+
+// SetCaptivePolicy sets the policy Verify applies to every singleton that has
+// not been registered with its own WithCaptivePolicy option.
+//
+// Parameters:
+//   - policy is one of CaptiveError, CaptiveStrict or CaptiveWarning.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the policy is not one of the above.
+func (this *Injector) SetCaptivePolicy(policy CaptivePolicy) error {
+	if err := assertCaptivePolicy(policy); err != nil {
+		return err
+	}
+
+	this.verified.Store(false)
+	this.captivePolicy = policy
+	return nil
+}
+
+// Warnings returns the problems found by the last call to Verify that did not
+// fail verification, such as captive dependencies allowed by CaptiveWarning.
+//
+// Returns:
+//   - warnings is sorted by message, and empty when there were no warnings.
+func (this *Injector) Warnings() (warnings []error) {
+	return slices.Clone(this.warnings)
+}
+
+func assertCaptivePolicy(policy CaptivePolicy) error {
+	if policy < contracts.CaptiveError || policy > contracts.CaptiveWarning {
+		return fmt.Errorf("%w: unknown captive policy [%d]", ErrorInvalidOption, policy)
+	}
+
+	return nil
+}
+
+// verifyCaptives walks the dependencies of a singleton that are constructed
+// along with it: transient registrations are followed, as the singleton keeps
+// them and everything they hold, while scoped registrations are reported.
+// Deferred dependencies are resolved in a scope of their own, so they are not
+// followed. The transient containers of groups, maps and factories only hold
+// their members, so they are followed without being reported themselves.
+func verifyCaptives(verification *verification, key contracts.KeyType, info *contracts.ObjectInfo) {
+	if info.Lifecycle != contracts.Singleton {
+		return
+	}
+
+	policy := info.CaptivePolicy
+	if policy == contracts.CaptiveDefault {
+		policy = verification.injector.captivePolicy
+	}
+
+	visited := make(map[contracts.KeyType]struct{})
+	var walk func(path []contracts.KeyType, owner *Injector)
+	walk = func(path []contracts.KeyType, owner *Injector) {
+		focus, _ := owner.library.Find(path[len(path)-1], search.NoReorder)
+		for iDependency, dependency := range focus.Dependencies {
+			if _, ok := visited[dependency]; ok || isDeferred(focus, iDependency) {
+				continue
+			}
+
+			visited[dependency] = struct{}{}
+			dependencyInfo, dependencyOwner, found := find(owner, dependency)
+			if !found || dependencyInfo.Lifecycle == contracts.Singleton {
+				continue
+			}
+
+			dependencyPath := append(slices.Clone(path), dependency)
+			if dependencyInfo.Lifecycle == contracts.Scope || (policy == contracts.CaptiveStrict && !dependencyInfo.Container) {
+				err := &CaptiveDependencyError{
+					Key:       dependency,
+					Lifecycle: dependencyInfo.Lifecycle,
+					Requester: key,
+					Path:      dependencyPath,
+				}
+
+				identity := "captive " + key.String() + " " + dependency.String()
+				if policy == contracts.CaptiveWarning {
+					verification.warn(identity, err)
+				} else {
+					verification.report(identity, err)
+				}
+			}
+
+			if dependencyInfo.Lifecycle == contracts.Transient {
+				walk(dependencyPath, dependencyOwner)
+			}
+		}
+	}
+
+	walk([]contracts.KeyType{key}, verification.injector)
+}
@@ -0,0 +1,62 @@
+package injector
+
+import (
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// NewChild creates an injector that falls back to this injector for every
+// type it has not registered itself. Registrations made in the child shadow
+// the registrations of this injector, without changing what this injector
+// resolves.
+//
+// Notes:
+//   - A type found in this injector is constructed by this injector, with
+//     its own dependencies, and its singletons are shared with every child.
+//   - Singletons registered in the child are owned by the child: they are
+//     started, stopped and disposed of by the child only.
+//   - The child is verified on its own. Types it resolves through this
+//     injector are checked to exist, but their own dependencies are left to
+//     this injector's Verify.
+//   - The child starts with the captive policy of this injector.
+//   - While this injector is closed, unverified or failed its verification,
+//     the child's Verify and every type resolved through this injector from
+//     the child return ErrorBadState.
+//
+// Parameters:
+//   - cacheStrategy defines an optional internal caching strategy for the
+//     child, see New.
+//
+// Returns:
+//   - child is a new, unverified injector.
+func (this *Injector) NewChild(cacheStrategy ...CacheStrategy) (child *Injector) {
+	child = New(cacheStrategy...)
+	child.parent = this
+	child.captivePolicy = this.captivePolicy
+	return child
+}
+
+// find looks the key up in the injector, then in each of its ancestors.
+//
+// Returns:
+//   - owner is the injector the key is registered in.
+func find(injector *Injector, key contracts.KeyType) (info *contracts.ObjectInfo, owner *Injector, found bool) {
+	for owner = injector; owner != nil; owner = owner.parent {
+		if info, found = owner.library.Find(key, search.NoReorder); found {
+			return info, owner, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// findName looks the name up in the injector, then in each of its ancestors.
+func findName(injector *Injector, name string) (key contracts.KeyType, found bool) {
+	for owner := injector; owner != nil; owner = owner.parent {
+		if key, found = owner.nameToKeyTrie.Find(name); found {
+			return key, true
+		}
+	}
+
+	return key, false
+}
@@ -0,0 +1,129 @@
+package injector
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// CallContext checks a function's signature then calls the function by
+// injecting all the arguments, like Call. Every context.Context parameter of
+// the function, and of the constructors run to resolve its arguments, receives
+// ctx.
+//
+// Notes:
+//   - ctx is checked before every constructor is invoked, so a canceled call
+//     stops at the next constructor rather than building the rest of the
+//     graph.
+//   - A singleton keeps the context it was constructed with.
+//
+// Parameters:
+//   - ctx is passed to context.Context parameters and checked for
+//     cancellation.
+//   - function is the function to be called with injected arguments.
+//
+// Returns:
+//   - err returns any error encountered during the call.
+//
+// Errors:
+//   - ctx.Err(), wrapped with the resolution path, when ctx is done before a
+//     constructor is invoked.
+//   - the same errors as Call.
+func (this *Injector) CallContext(ctx context.Context, function any) (err error) {
+	_, err = this.callN(ctx, function, 0)
+	return err
+}
+
+// GetContext retrieves the given type like Get. Every context.Context
+// parameter of the constructors run to resolve the type receives ctx.
+//
+// Notes:
+//   - ctx is checked before every constructor is invoked, so a canceled call
+//     stops at the next constructor rather than building the rest of the
+//     graph.
+//   - A singleton keeps the context it was constructed with.
+//
+// Parameters:
+//   - ctx is passed to context.Context parameters and checked for
+//     cancellation.
+//   - key is the type to look for a registered instance or constructor for.
+//
+// Returns:
+//   - The registered instance or the result of the registered constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ctx.Err(), wrapped with the resolution path, when ctx is done before a
+//     constructor is invoked.
+//   - the same errors as Get.
+func (this *Injector) GetContext(ctx context.Context, key reflect.Type) (value any, err error) {
+	return this.resolve(ctx, contracts.NewKey(key, ""))
+}
+
+// CallContext checks a function's signature then calls the function by
+// injecting all the arguments, like Call. Every context.Context parameter of
+// the function, and of the constructors run to resolve its arguments, receives
+// ctx.
+//
+// Notes:
+//   - ctx is checked before every constructor is invoked, so a canceled call
+//     stops at the next constructor rather than building the rest of the
+//     graph.
+//   - A singleton keeps the context it was constructed with.
+//
+// Parameters:
+//   - ctx is passed to context.Context parameters and checked for
+//     cancellation.
+//   - injector is the dependency injector to use when making the function call.
+//   - function is the function to be called with injected arguments.
+//
+// Returns:
+//   - err returns any error encountered during the call.
+//
+// Errors:
+//   - ctx.Err(), wrapped with the resolution path, when ctx is done before a
+//     constructor is invoked.
+//   - the same errors as Call.
+func CallContext(ctx context.Context, injector *Injector, function any) (err error) {
+	return injector.CallContext(ctx, function)
+}
+
+// GetContext retrieves the given type like Get. Every context.Context
+// parameter of the constructors run to resolve the type receives ctx.
+//
+// Notes:
+//   - ctx is checked before every constructor is invoked, so a canceled call
+//     stops at the next constructor rather than building the rest of the
+//     graph.
+//   - A singleton keeps the context it was constructed with.
+//
+// Parameters:
+//   - ctx is passed to context.Context parameters and checked for
+//     cancellation.
+//   - injector is the dependency injector to get the instance from.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ctx.Err(), wrapped with the resolution path, when ctx is done before a
+//     constructor is invoked.
+//   - the same errors as Get.
+func GetContext[Tkey any](ctx context.Context, injector *Injector) (value Tkey, err error) {
+	var rawValue any
+	rawValue, err = injector.GetContext(ctx, reflect.TypeFor[Tkey]())
+	if err != nil {
+		return value, err
+	}
+
+	return rawValue.(Tkey), nil
+}
+
+// isContextKey reports whether the key is a context.Context parameter, which
+// receives the context of the resolution instead of being registered.
+func isContextKey(key contracts.KeyType) bool {
+	return key.Type == reflect.TypeFor[context.Context]()
+}
@@ -0,0 +1,165 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// Decorate wraps the value of an already registered type. The decorator
+// receives the inner value as its first parameter, every other parameter is
+// injected like a constructor parameter, and its single return value replaces
+// the inner value.
+//
+// Notes:
+//   - Decorators stack: the first decorator registered wraps the constructed
+//     value, every following decorator wraps the previous one.
+//   - The lifecycle of the registration is unchanged, so a decorated
+//     singleton is decorated exactly once.
+//   - A decorated instance added with RegisterInstance is still owned by the
+//     caller, so it is never closed by the injector.
+//
+// Parameters:
+//   - key is the registered type to decorate.
+//   - decorator is a function of the form func(inner key, deps...) key.
+//
+// Errors:
+//   - ErrorNotAFunction is returned when a non-function is passed as a
+//     decorator.
+//   - ErrorNotAssignable is returned when the decorator does not accept the
+//     key type as its first parameter or does not return exactly one value
+//     assignable to the key type.
+//   - ErrorNotRegistered is returned when the key type has not been
+//     registered yet.
+//   - ErrorNotStructOrInterface is returned when the key type is a group, a
+//     map or a factory. Groups and maps are rebuilt by every member added to
+//     them, which would drop the decorator.
+//   - ErrorVariadicArguments is returned when a decorator has a variadic
+//     signature.
+func (this *Injector) Decorate(key reflect.Type, decorator any) error {
+	return decorate(this, contracts.NewKey(key, ""), reflect.ValueOf(decorator))
+}
+
+// Decorate wraps the value of an already registered type. The decorator
+// receives the inner value as its first parameter, every other parameter is
+// injected like a constructor parameter, and its single return value replaces
+// the inner value.
+//
+// Notes:
+//   - Decorators stack: the first decorator registered wraps the constructed
+//     value, every following decorator wraps the previous one.
+//   - The lifecycle of the registration is unchanged, so a decorated
+//     singleton is decorated exactly once.
+//   - A decorated instance added with RegisterInstance is still owned by the
+//     caller, so it is never closed by the injector.
+//
+// Parameters:
+//   - target is the Injector the type was registered in.
+//   - decorator is a function of the form func(inner Tkey, deps...) Tkey.
+//
+// Errors:
+//   - ErrorNotAFunction is returned when a non-function is passed as a
+//     decorator.
+//   - ErrorNotAssignable is returned when the decorator does not accept the
+//     key type as its first parameter or does not return exactly one value
+//     assignable to the key type.
+//   - ErrorNotRegistered is returned when the key type has not been
+//     registered yet.
+//   - ErrorNotStructOrInterface is returned when the key type is a group, a
+//     map or a factory. Groups and maps are rebuilt by every member added to
+//     them, which would drop the decorator.
+//   - ErrorVariadicArguments is returned when a decorator has a variadic
+//     signature.
+func Decorate[Tkey any](target *Injector, decorator any) error {
+	return target.Decorate(reflect.TypeFor[Tkey](), decorator)
+}
+
+func decorate(target *Injector, key contracts.KeyType, decorator reflect.Value) error {
+	target.verified.Store(false)
+	if decorator.Kind() != reflect.Func {
+		return fmt.Errorf(
+			"%w: decorator for type '%s'",
+			ErrorNotAFunction,
+			key.Name())
+	}
+
+	decoratorType := decorator.Type()
+	if decoratorType.IsVariadic() {
+		return fmt.Errorf(
+			"%w: decorator for type '%s'",
+			ErrorVariadicArguments,
+			key.Name())
+	}
+
+	if decoratorType.NumIn() == 0 || decoratorType.NumOut() != 1 ||
+		!key.Type.AssignableTo(decoratorType.In(0)) || !decoratorType.Out(0).AssignableTo(key.Type) {
+		return fmt.Errorf(
+			"%w: decorator for type '%s' must be of the form func(inner %s, deps...) %s",
+			ErrorNotAssignable,
+			key.Name(),
+			key.Type.String(),
+			key.Type.String())
+	}
+
+	info, found := target.library.Find(key, search.NoReorder)
+	if !found {
+		return fmt.Errorf(
+			"%w: decorated type '%s' must be registered before it is decorated",
+			ErrorNotRegistered,
+			key.Name())
+	}
+
+	if info.Container {
+		return fmt.Errorf(
+			"%w: decorated type '%s' is a group, map or factory",
+			ErrorNotStructOrInterface,
+			key.Name())
+	}
+
+	original := reflect.Value(info.ConstructorValue)
+	originalType := reflect.Type(info.ConstructorType)
+	originalCount := originalType.NumIn()
+
+	parameters := make([]reflect.Type, 0, originalCount+decoratorType.NumIn()-1)
+	for iParameter := 0; iParameter < originalCount; iParameter++ {
+		parameters = append(parameters, originalType.In(iParameter))
+	}
+
+	info.Deferred = append(info.Deferred, make([]bool, len(info.Dependencies)-len(info.Deferred))...)
+	info.Optional = append(info.Optional, make([]bool, len(info.Dependencies)-len(info.Optional))...)
+	for iParameter := 1; iParameter < decoratorType.NumIn(); iParameter++ {
+		parameters = append(parameters, decoratorType.In(iParameter))
+		dependency, isDeferred, isOptional := dependencyKey(decoratorType.In(iParameter), "")
+		info.Dependencies = append(info.Dependencies, dependency)
+		info.Deferred = append(info.Deferred, isDeferred)
+		info.Optional = append(info.Optional, isOptional)
+	}
+
+	returns := []reflect.Type{decoratorType.Out(0)}
+	if info.ConstructorReturnsError {
+		returns = append(returns, originalType.Out(1))
+	}
+
+	constructorType := reflect.FuncOf(parameters, returns, false)
+	constructorValue := reflect.MakeFunc(constructorType, func(arguments []reflect.Value) []reflect.Value {
+		inner := original.Call(arguments[:originalCount])
+		if info.ConstructorReturnsError && !inner[1].IsNil() {
+			return []reflect.Value{reflect.Zero(returns[0]), inner[1]}
+		}
+
+		decoratorArguments := append([]reflect.Value{inner[0]}, arguments[originalCount:]...)
+		decorated := decorator.Call(decoratorArguments)
+		if info.ConstructorReturnsError {
+			return []reflect.Value{decorated[0], inner[1]}
+		}
+
+		return decorated
+	})
+
+	info.ConstructorType = contracts.ConstructorType(constructorType)
+	info.ConstructorValue = contracts.ConstructorValue(constructorValue)
+	info.Singleton = nil
+	return nil
+}
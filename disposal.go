@@ -0,0 +1,118 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"slices"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// Close shuts the injector down using a background context, see
+// [Injector.Shutdown].
+//
+// Returns:
+//   - err joins every error returned while disposing of the singletons.
+func (this *Injector) Close() error {
+	return this.Shutdown(context.Background())
+}
+
+// Shutdown disposes of every singleton constructed by this injector, in
+// reverse dependency order, then moves the injector into a closed state where
+// every Get or Call returns ErrorBadState. A started injector is stopped first,
+// see [Injector.Stop]. Singletons implementing [Stopper] are then stopped
+// unless already stopped, otherwise singletons implementing io.Closer are
+// closed. Singletons that were never constructed and instances registered
+// through RegisterInstance are left untouched.
+//
+// Parameters:
+//   - ctx is passed to every Stop method. Once ctx is done, the remaining
+//     singletons are not disposed of, until a later Close or Shutdown.
+//
+// Returns:
+//   - err joins every error returned while disposing of the singletons.
+func (this *Injector) Shutdown(ctx context.Context) (err error) {
+	err = this.Stop(ctx)
+
+	this.singletonsMutex.Lock()
+	this.closed.Store(true)
+	this.singletonsMutex.Unlock()
+
+	this.lifecycleMutex.Lock()
+	defer this.lifecycleMutex.Unlock()
+
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(err, ctxErr)
+		}
+
+		info, found := this.lastSingleton()
+		if !found {
+			return err
+		}
+
+		info.Mutex.Lock()
+		err = errors.Join(err, disposeInstance(ctx, info))
+		info.Singleton = nil
+		info.Mutex.Unlock()
+		this.removeSingleton(info)
+	}
+}
+
+// closeInstance closes the instance if it implements io.Closer.
+func closeInstance(instance any) error {
+	if closer, ok := unwrapInstance(instance).(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// disposeInstance stops the singleton if it implements Stopper and has not
+// already been stopped by Injector.Stop, otherwise it closes the singleton if
+// it implements io.Closer.
+func disposeInstance(ctx context.Context, info *contracts.ObjectInfo) error {
+	if stopper, ok := unwrapInstance(info.Singleton).(Stopper); ok {
+		if info.Stopped {
+			return nil
+		}
+
+		return stopper.Stop(ctx)
+	}
+
+	return closeInstance(info.Singleton)
+}
+
+// lastSingleton returns the most recently constructed singleton that has not
+// been disposed of yet.
+func (this *Injector) lastSingleton() (info *contracts.ObjectInfo, found bool) {
+	this.singletonsMutex.Lock()
+	defer this.singletonsMutex.Unlock()
+
+	if len(this.singletons) == 0 {
+		return nil, false
+	}
+
+	return this.singletons[len(this.singletons)-1], true
+}
+
+// removeSingleton forgets a disposed singleton, so that an interrupted
+// Shutdown resumes with the singleton constructed before it.
+func (this *Injector) removeSingleton(info *contracts.ObjectInfo) {
+	this.singletonsMutex.Lock()
+	defer this.singletonsMutex.Unlock()
+
+	this.singletons = slices.DeleteFunc(this.singletons, func(singleton *contracts.ObjectInfo) bool { return singleton == info })
+}
+
+// unwrapInstance returns the instance itself. Instances are held either as
+// reflect.Value (when built by a constructor) or as-is.
+func unwrapInstance(instance any) any {
+	if value, ok := instance.(reflect.Value); ok {
+		return value.Interface()
+	}
+
+	return instance
+}
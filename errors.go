@@ -3,6 +3,7 @@ package injector
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
@@ -18,10 +19,29 @@ var (
 	// injector that is in a bad state.
 	ErrorBadState = fmt.Errorf("%w, bad injector state", InjectorError)
 
+	// ErrorCaptiveDependency indicates that a singleton depends on a
+	// registration with a shorter lifecycle.
+	ErrorCaptiveDependency = fmt.Errorf("%w, captive dependency", InjectorError)
+
+	// ErrorConstructor is returned when a constructor returns an error.
+	ErrorConstructor = fmt.Errorf("%w, constructor returned an error", InjectorError)
+
 	// ErrorDependencyLoop indicates that an unsolvable dependency injection
 	// loop.
 	ErrorDependencyLoop = fmt.Errorf("%w, dependency loop detected", InjectorError)
 
+	// ErrorDuplicateMapKey is returned when more than one map entry has been
+	// contributed under the same key.
+	ErrorDuplicateMapKey = fmt.Errorf("%w, duplicate map key", InjectorError)
+
+	// ErrorInvalidOption is returned when a registration option cannot be
+	// applied to the registration it was passed with.
+	ErrorInvalidOption = fmt.Errorf("%w, invalid registration option", InjectorError)
+
+	// ErrorInvalidTag is returned when a `di` struct tag cannot be applied to
+	// the field it is attached to.
+	ErrorInvalidTag = fmt.Errorf("%w, invalid struct tag", InjectorError)
+
 	// ErrorNoReturns is returned when a constructor has no return value.
 	ErrorNoReturns = fmt.Errorf("%w, no return values, must be exactly 1 return value", InjectorError)
 
@@ -51,3 +71,183 @@ var (
 	// than the expected number of return values.
 	ErrorWrongNumberOfReturns = fmt.Errorf("%w, wrong number of return values", InjectorError)
 )
+
+// CaptiveDependencyError is reported by Verify when a singleton depends,
+// directly or through transient registrations, on a registration with a
+// shorter lifecycle, which would keep it alive for the lifetime of the
+// injector. It matches ErrorCaptiveDependency through errors.Is.
+type CaptiveDependencyError struct {
+	// Key is the captured registration.
+	Key Key
+
+	// Lifecycle is the lifecycle of the captured registration.
+	Lifecycle Lifecycle
+
+	// Requester is the singleton capturing Key.
+	Requester Key
+
+	// Path is the chain of registrations from Requester down to Key.
+	Path []Key
+}
+
+func (this *CaptiveDependencyError) Error() string {
+	return fmt.Sprintf("%s: singleton '%s' captures %s '%s'\n\t%s", ErrorCaptiveDependency, this.Requester.String(), this.Lifecycle, this.Key.String(), formatPath(this.Path))
+}
+
+func (this *CaptiveDependencyError) Unwrap() error {
+	return ErrorCaptiveDependency
+}
+
+// ConstructorError is returned when the constructor of a registration returns
+// an error. It matches both ErrorConstructor and the constructor's own error
+// through errors.Is.
+type ConstructorError struct {
+	// Key is the registration whose constructor failed.
+	Key Key
+
+	// Path is the chain of registrations being resolved, from the requested
+	// key down to Key.
+	Path []Key
+
+	// Err is the error returned by the constructor.
+	Err error
+}
+
+func (this *ConstructorError) Error() string {
+	return fmt.Sprintf("%s: type '%s': %s\n\t%s", ErrorConstructor, this.Key.Name(), this.Err, formatPath(this.Path))
+}
+
+func (this *ConstructorError) Unwrap() []error {
+	return []error{ErrorConstructor, this.Err}
+}
+
+func (this *ConstructorError) prependPath(key Key) {
+	if len(this.Path) == 0 {
+		this.Key = key
+	}
+
+	this.Path = append([]Key{key}, this.Path...)
+}
+
+// DependencyLoopError is returned when a registration depends on itself,
+// directly or through its dependencies. It matches ErrorDependencyLoop through
+// errors.Is.
+type DependencyLoopError struct {
+	// Key is the registration that closes the loop.
+	Key Key
+
+	// Requester is the registration depending on Key.
+	Requester Key
+
+	// Path is the chain of registrations from the verified key down to Key.
+	Path []Key
+}
+
+func (this *DependencyLoopError) Error() string {
+	return fmt.Sprintf("%s: type '%s' required by '%s'\n\t%s", ErrorDependencyLoop, this.Key.Name(), this.Requester.Name(), formatPath(this.Path))
+}
+
+func (this *DependencyLoopError) Unwrap() error {
+	return ErrorDependencyLoop
+}
+
+// NotRegisteredError is returned when a requested type, or one of its
+// dependencies, has not been registered. It matches ErrorNotRegistered through
+// errors.Is.
+type NotRegisteredError struct {
+	// Key is the missing registration.
+	Key Key
+
+	// Requester is the registration depending on Key. It is the zero Key when
+	// Key was requested directly.
+	Requester Key
+
+	// Path is the chain of registrations being resolved, from the requested
+	// key down to Key.
+	Path []Key
+}
+
+func (this *NotRegisteredError) Error() string {
+	if this.Requester.Type == nil {
+		return fmt.Sprintf("%s: type '%s'\n\t%s", ErrorNotRegistered, this.Key.Name(), formatPath(this.Path))
+	}
+
+	return fmt.Sprintf("%s: type '%s' required by '%s'\n\t%s", ErrorNotRegistered, this.Key.Name(), this.Requester.Name(), formatPath(this.Path))
+}
+
+func (this *NotRegisteredError) Unwrap() error {
+	return ErrorNotRegistered
+}
+
+func (this *NotRegisteredError) prependPath(key Key) {
+	this.Path = append([]Key{key}, this.Path...)
+	if len(this.Path) > 1 {
+		this.Requester = this.Path[len(this.Path)-2]
+	}
+}
+
+// VerificationError lists every problem found by Verify, sorted by message.
+// Each problem wraps the sentinel error describing it, so errors.Is matches any
+// of them.
+type VerificationError struct {
+	Problems []error
+}
+
+func (this *VerificationError) Error() string {
+	messages := make([]string, len(this.Problems))
+	for iProblem, problem := range this.Problems {
+		messages[iProblem] = problem.Error()
+	}
+
+	return fmt.Sprintf("verification found [%d] problem(s):\n%s", len(this.Problems), strings.Join(messages, "\n"))
+}
+
+func (this *VerificationError) Unwrap() []error {
+	return this.Problems
+}
+
+// pathError is implemented by errors that record the resolution path leading
+// to them.
+type pathError interface {
+	error
+	prependPath(key Key)
+}
+
+// resolutionError carries the chain of registrations that were being resolved
+// when err occurred, outermost first.
+type resolutionError struct {
+	path []Key
+	err  error
+}
+
+func (this *resolutionError) Error() string {
+	return fmt.Sprintf("%s\n\t%s", this.err, formatPath(this.path))
+}
+
+func (this *resolutionError) Unwrap() error {
+	return this.err
+}
+
+func (this *resolutionError) prependPath(key Key) {
+	this.path = append([]Key{key}, this.path...)
+}
+
+// formatPath renders a dependency path as "A -> B -> C".
+func formatPath(path []Key) string {
+	names := make([]string, len(path))
+	for iKey, key := range path {
+		names[iKey] = key.String()
+	}
+
+	return strings.Join(names, " -> ")
+}
+
+// withPath prepends the key to the resolution path of err.
+func withPath(key Key, err error) error {
+	if pathErr, ok := err.(pathError); ok {
+		pathErr.prependPath(key)
+		return pathErr
+	}
+
+	return &resolutionError{path: []Key{key}, err: err}
+}
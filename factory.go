@@ -0,0 +1,219 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// RegisterFactory registers a factory function type, such as
+// func(tenant string) Repository, for types that need runtime arguments as
+// well as injected dependencies. The injector synthesizes the factory from a
+// constructor whose leading parameters are injected and whose trailing
+// parameters are the factory's own parameters, forwarded positionally.
+//
+// Notes:
+//   - The injected parameters take part in Verify, loop detection and
+//     captive detection like the parameters of any other registration.
+//   - The factory is registered as a transient: the injected parameters are
+//     resolved each time the factory itself is injected, and shared by every
+//     call made to that factory.
+//   - The factory may return (T, error), in which case the constructor may
+//     also return an error, which is returned by the factory as it is.
+//
+// Parameters:
+//   - key is the factory function type to register.
+//   - constructor is the function called by the factory. Its parameters are
+//     the injected dependencies followed by the factory's parameters.
+//   - options customize the registration. Only the injected parameters are
+//     parameters for WithNamedParameter.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when the factory type has already
+//     been registered.
+//   - ErrorNotAFunction is returned when the key type or the constructor is
+//     not a function.
+//   - ErrorNotAssignable is returned when the factory's parameters cannot be
+//     forwarded to the last parameters of the constructor, or when the
+//     constructor's return type cannot be assigned to the factory's.
+//   - ErrorVariadicArguments is returned when the factory or the constructor
+//     is variadic.
+//   - ErrorWrongNumberOfReturns is returned when the factory does not return
+//     T or (T, error), or when the constructor returns an error that the
+//     factory cannot return.
+func (this *Injector) RegisterFactory(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	constructorType, constructorValue, err := factoryConstructor(key, reflect.ValueOf(constructor))
+	if err != nil {
+		return err
+	}
+
+	info := &contracts.ObjectInfo{
+		ConstructorType:  constructorType,
+		ConstructorValue: constructorValue,
+		Lifecycle:        contracts.Transient,
+		Container:        true,
+	}
+
+	return store(this, contracts.NewKey(key, ""), info, options)
+}
+
+// RegisterFactory registers a factory function type, such as
+// func(tenant string) Repository, for types that need runtime arguments as
+// well as injected dependencies. The injector synthesizes the factory from a
+// constructor whose leading parameters are injected and whose trailing
+// parameters are the factory's own parameters, forwarded positionally.
+//
+// Notes:
+//   - The injected parameters take part in Verify, loop detection and
+//     captive detection like the parameters of any other registration.
+//   - The factory is registered as a transient: the injected parameters are
+//     resolved each time the factory itself is injected, and shared by every
+//     call made to that factory.
+//   - The factory may return (T, error), in which case the constructor may
+//     also return an error, which is returned by the factory as it is.
+//
+// Parameters:
+//   - target is the Injector to register the factory in.
+//   - constructor is the function called by the factory. Its parameters are
+//     the injected dependencies followed by the factory's parameters.
+//   - options customize the registration. Only the injected parameters are
+//     parameters for WithNamedParameter.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when the factory type has already
+//     been registered.
+//   - ErrorNotAFunction is returned when Tfactory or the constructor is not a
+//     function.
+//   - ErrorNotAssignable is returned when the factory's parameters cannot be
+//     forwarded to the last parameters of the constructor, or when the
+//     constructor's return type cannot be assigned to the factory's.
+//   - ErrorVariadicArguments is returned when the factory or the constructor
+//     is variadic.
+//   - ErrorWrongNumberOfReturns is returned when the factory does not return
+//     T or (T, error), or when the constructor returns an error that the
+//     factory cannot return.
+func RegisterFactory[Tfactory any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterFactory(reflect.TypeFor[Tfactory](), constructor, options...)
+}
+
+// factoryConstructor generates a constructor taking the injected parameters
+// of the given constructor and returning the factory, which calls the given
+// constructor with the injected values followed by its own arguments.
+func factoryConstructor(factoryType reflect.Type, constructor reflect.Value) (contracts.ConstructorType, contracts.ConstructorValue, error) {
+	if err := validateFactory(factoryType, constructor); err != nil {
+		return nil, contracts.ConstructorValue{}, err
+	}
+
+	constructorType := constructor.Type()
+	injectedCount := constructorType.NumIn() - factoryType.NumIn()
+	parameters := make([]reflect.Type, injectedCount)
+	for iParameter := range parameters {
+		parameters[iParameter] = constructorType.In(iParameter)
+	}
+
+	returnsError := factoryType.NumOut() == 2
+	generatedType := reflect.FuncOf(parameters, []reflect.Type{factoryType}, false)
+	generatedValue := reflect.MakeFunc(generatedType, func(injected []reflect.Value) []reflect.Value {
+		factory := reflect.MakeFunc(factoryType, func(arguments []reflect.Value) []reflect.Value {
+			returns := constructor.Call(append(slices.Clone(injected), arguments...))
+			value := reflect.New(factoryType.Out(0)).Elem()
+			value.Set(returns[0])
+			if !returnsError {
+				return []reflect.Value{value}
+			}
+
+			err := reflect.Zero(factoryType.Out(1))
+			if len(returns) == 2 {
+				err = returns[1]
+			}
+
+			return []reflect.Value{value, err}
+		})
+
+		return []reflect.Value{factory}
+	})
+
+	return contracts.ConstructorType(generatedType), contracts.ConstructorValue(generatedValue), nil
+}
+
+// validateFactory checks that the factory type can be synthesized from the
+// constructor.
+func validateFactory(factoryType reflect.Type, constructor reflect.Value) error {
+	if factoryType.Kind() != reflect.Func {
+		return fmt.Errorf(
+			"%w: factory type '%s' must be a function type",
+			ErrorNotAFunction,
+			factoryType.String())
+	}
+
+	if constructor.Kind() != reflect.Func {
+		return fmt.Errorf(
+			"%w: constructor for factory '%s'",
+			ErrorNotAFunction,
+			factoryType.String())
+	}
+
+	constructorType := constructor.Type()
+	if factoryType.IsVariadic() || constructorType.IsVariadic() {
+		return fmt.Errorf(
+			"%w: factory '%s'",
+			ErrorVariadicArguments,
+			factoryType.String())
+	}
+
+	errorType := reflect.TypeFor[error]()
+	factoryReturnsError := factoryType.NumOut() == 2 && factoryType.Out(1) == errorType
+	if factoryType.NumOut() != 1 && !factoryReturnsError {
+		return fmt.Errorf(
+			"%w: factory '%s' must return T or (T, error)",
+			ErrorWrongNumberOfReturns,
+			factoryType.String())
+	}
+
+	constructorReturnsError := constructorType.NumOut() == 2 && constructorType.Out(1) == errorType
+	if constructorType.NumOut() != 1 && !constructorReturnsError {
+		return fmt.Errorf(
+			"%w: constructor for factory '%s' must return T or (T, error)",
+			ErrorWrongNumberOfReturns,
+			factoryType.String())
+	}
+
+	if constructorReturnsError && !factoryReturnsError {
+		return fmt.Errorf(
+			"%w: constructor for factory '%s' returns an error, so the factory must return (T, error)",
+			ErrorWrongNumberOfReturns,
+			factoryType.String())
+	}
+
+	if !constructorType.Out(0).AssignableTo(factoryType.Out(0)) {
+		return fmt.Errorf(
+			"%w: constructor's return type '%s' is not assignable to the return type of factory '%s'",
+			ErrorNotAssignable,
+			constructorType.Out(0).String(),
+			factoryType.String())
+	}
+
+	injectedCount := constructorType.NumIn() - factoryType.NumIn()
+	if injectedCount < 0 {
+		return fmt.Errorf(
+			"%w: constructor for factory '%s' must end with the factory's [%d] parameters",
+			ErrorNotAssignable,
+			factoryType.String(),
+			factoryType.NumIn())
+	}
+
+	for iParameter := range factoryType.NumIn() {
+		if !factoryType.In(iParameter).AssignableTo(constructorType.In(injectedCount + iParameter)) {
+			return fmt.Errorf(
+				"%w: parameter [%d] of factory '%s' is not assignable to parameter [%d] of its constructor",
+				ErrorNotAssignable,
+				iParameter,
+				factoryType.String(),
+				injectedCount+iParameter)
+		}
+	}
+
+	return nil
+}
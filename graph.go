@@ -0,0 +1,206 @@
+package injector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// GraphFormat is one of the formats the dependency graph can be written in.
+type GraphFormat int
+
+const (
+	// GraphDOT writes the graph as a Graphviz digraph.
+	GraphDOT GraphFormat = iota
+
+	// GraphMermaid writes the graph as a Mermaid flowchart.
+	GraphMermaid
+
+	// GraphJSON writes the graph as a JSON document with a list of nodes and a
+	// list of edges.
+	GraphJSON
+)
+
+// WriteGraph writes the dependency graph of every registration: one node per
+// key with its lifecycle and whether it has been instantiated, and one edge
+// per constructor parameter, from the registration to its dependency. Keys
+// provided by a parent injector are included with their lifecycle, other keys
+// that are depended on without being registered are included as unregistered
+// nodes.
+//
+// Notes:
+//   - Nodes and edges are sorted, so the output of an unchanged injector can
+//     be diffed.
+//   - Only singletons are ever reported as instantiated.
+//
+// Parameters:
+//   - writer receives the graph.
+//   - format is the format to write the graph in.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the format is unknown.
+//   - any error returned by the writer.
+func (this *Injector) WriteGraph(writer io.Writer, format GraphFormat) error {
+	graph := buildGraph(this)
+	switch format {
+	case GraphDOT:
+		return writeDOT(writer, graph)
+	case GraphMermaid:
+		return writeMermaid(writer, graph)
+	case GraphJSON:
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(graph)
+	default:
+		return fmt.Errorf("%w: unknown graph format [%d]", ErrorInvalidOption, format)
+	}
+}
+
+type graphNode struct {
+	Key          string `json:"key"`
+	Lifecycle    string `json:"lifecycle,omitempty"`
+	Registered   bool   `json:"registered"`
+	Instantiated bool   `json:"instantiated"`
+}
+
+type graphEdge struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Parameter int    `json:"parameter"`
+}
+
+type dependencyGraph struct {
+	Nodes []graphNode `json:"nodes"`
+	Edges []graphEdge `json:"edges"`
+}
+
+// buildGraph walks the registrations in the same sorted order Verify does. An
+// edge refers to its nodes by key, so that adding a registration only adds
+// lines to the output.
+func buildGraph(injector *Injector) *dependencyGraph {
+	graph := &dependencyGraph{Nodes: make([]graphNode, 0), Edges: make([]graphEdge, 0)}
+	registrations := slices.Collect(injector.Registrations())
+	registered := make(map[contracts.KeyType]struct{}, len(registrations))
+	for _, registration := range registrations {
+		registered[registration.Key] = struct{}{}
+		graph.Nodes = append(graph.Nodes, graphNode{
+			Key:          registration.Key.String(),
+			Lifecycle:    registration.Lifecycle.String(),
+			Registered:   true,
+			Instantiated: registration.Instantiated,
+		})
+	}
+
+	for _, registration := range registrations {
+		for iParameter, dependency := range registration.Dependencies {
+			if isContextKey(dependency) {
+				continue
+			}
+
+			if _, found := registered[dependency]; !found {
+				registered[dependency] = struct{}{}
+				graph.Nodes = append(graph.Nodes, inheritedNode(injector, dependency))
+			}
+
+			graph.Edges = append(graph.Edges, graphEdge{From: registration.Key.String(), To: dependency.String(), Parameter: iParameter})
+		}
+	}
+
+	return graph
+}
+
+func graphLifecycle(node graphNode) string {
+	if !node.Registered {
+		return "unregistered"
+	}
+
+	return node.Lifecycle
+}
+
+// inheritedNode describes a dependency that is not registered in the injector
+// itself, either as provided by a parent injector or as unregistered.
+func inheritedNode(injector *Injector, key contracts.KeyType) graphNode {
+	if injector.parent != nil {
+		if info, _, found := find(injector.parent, key); found {
+			registration := describe(key, info)
+			return graphNode{
+				Key:          key.String(),
+				Lifecycle:    registration.Lifecycle.String(),
+				Registered:   true,
+				Instantiated: registration.Instantiated,
+			}
+		}
+	}
+
+	return graphNode{Key: key.String()}
+}
+
+// mermaidID derives the node id from the key, so that adding a registration
+// does not renumber every other node. Every character that is not a letter
+// or a digit is escaped as its hex code between underscores, so two distinct
+// keys never share an id.
+func mermaidID(key string) string {
+	builder := &strings.Builder{}
+	for _, character := range key {
+		switch {
+		case 'a' <= character && character <= 'z', 'A' <= character && character <= 'Z', '0' <= character && character <= '9':
+			builder.WriteRune(character)
+		default:
+			_, _ = fmt.Fprintf(builder, "_%x_", character)
+		}
+	}
+
+	return builder.String()
+}
+
+func writeDOT(writer io.Writer, graph *dependencyGraph) error {
+	builder := &strings.Builder{}
+	builder.WriteString("digraph injector {\n")
+	for _, node := range graph.Nodes {
+		style := ""
+		switch {
+		case !node.Registered:
+			style = ", style=dashed"
+		case node.Instantiated:
+			style = ", style=filled"
+		}
+
+		_, _ = fmt.Fprintf(builder, "\t%q [label=%q%s];\n", node.Key, node.Key+"\n"+graphLifecycle(node), style)
+	}
+
+	for _, edge := range graph.Edges {
+		_, _ = fmt.Fprintf(builder, "\t%q -> %q [label=\"%d\"];\n", edge.From, edge.To, edge.Parameter)
+	}
+
+	builder.WriteString("}\n")
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+func writeMermaid(writer io.Writer, graph *dependencyGraph) error {
+	builder := &strings.Builder{}
+	builder.WriteString("flowchart LR\n")
+	builder.WriteString("\tclassDef instantiated fill:#cfc\n")
+	builder.WriteString("\tclassDef unregistered stroke-dasharray:5 5\n")
+	for _, node := range graph.Nodes {
+		id := mermaidID(node.Key)
+		_, _ = fmt.Fprintf(builder, "\t%s[\"%s<br/>%s\"]\n", id, node.Key, graphLifecycle(node))
+		switch {
+		case !node.Registered:
+			_, _ = fmt.Fprintf(builder, "\tclass %s unregistered\n", id)
+		case node.Instantiated:
+			_, _ = fmt.Fprintf(builder, "\tclass %s instantiated\n", id)
+		}
+	}
+
+	for _, edge := range graph.Edges {
+		_, _ = fmt.Fprintf(builder, "\t%s -->|%d| %s\n", mermaidID(edge.From), edge.Parameter, mermaidID(edge.To))
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
@@ -0,0 +1,153 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// RegisterGroupMember adds a constructor as one more member of the group of
+// the given type. Any constructor or function declaring a parameter of type
+// []key receives every member of the group, in registration order.
+//
+// Notes:
+//   - Each member keeps its own lifecycle, the slice itself is rebuilt every
+//     time it is requested.
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use
+//     [Injector.RegisterGroupMemberError].
+//
+// Parameters:
+//   - key is the element type of the group.
+//   - lifecycle is the lifecycle of this member.
+//   - constructor is the requisite function to generate the member.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingleton], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) RegisterGroupMember(key reflect.Type, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        lifecycle,
+	}
+
+	return registerGroupMember(this, key, info, options)
+}
+
+// RegisterGroupMemberError adds a constructor as one more member of the group
+// of the given type. Any constructor or function declaring a parameter of type
+// []key receives every member of the group, in registration order.
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use [Injector.RegisterGroupMember] instead.
+//
+// Parameters:
+//   - key is the element type of the group.
+//   - lifecycle is the lifecycle of this member.
+//   - constructor is the requisite function to generate the member.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingletonError], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) RegisterGroupMemberError(key reflect.Type, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:               lifecycle,
+		ConstructorReturnsError: true,
+	}
+
+	return registerGroupMember(this, key, info, options)
+}
+
+// RegisterGroupMember adds a constructor as one more member of the group of
+// the given type. Any constructor or function declaring a parameter of type
+// []Tkey receives every member of the group, in registration order.
+//
+// Notes:
+//   - Each member keeps its own lifecycle, the slice itself is rebuilt every
+//     time it is requested.
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use RegisterGroupMemberError.
+//
+// Parameters:
+//   - target is the Injector to register the member in.
+//   - lifecycle is the lifecycle of this member.
+//   - constructor is the requisite function to generate the member.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingleton, except that
+//     ErrorAlreadyRegistered is never returned.
+func RegisterGroupMember[Tkey any](target *Injector, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	return target.RegisterGroupMember(reflect.TypeFor[Tkey](), lifecycle, constructor, options...)
+}
+
+// RegisterGroupMemberError adds a constructor as one more member of the group
+// of the given type. Any constructor or function declaring a parameter of type
+// []Tkey receives every member of the group, in registration order.
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use RegisterGroupMember instead.
+//
+// Parameters:
+//   - target is the Injector to register the member in.
+//   - lifecycle is the lifecycle of this member.
+//   - constructor is the requisite function to generate the member.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingletonError, except that
+//     ErrorAlreadyRegistered is never returned.
+func RegisterGroupMemberError[Tkey any](target *Injector, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	return target.RegisterGroupMemberError(reflect.TypeFor[Tkey](), lifecycle, constructor, options...)
+}
+
+// groupConstructor generates a constructor taking one parameter per member
+// and appending them to a new slice in registration order. It is generated
+// again every time a member joins the group.
+func groupConstructor(sliceType reflect.Type, memberCount int) (contracts.ConstructorType, contracts.ConstructorValue) {
+	parameters := make([]reflect.Type, memberCount)
+	for iParameter := range parameters {
+		parameters[iParameter] = sliceType.Elem()
+	}
+
+	constructorType := reflect.FuncOf(parameters, []reflect.Type{sliceType}, false)
+	constructorValue := reflect.MakeFunc(constructorType, func(members []reflect.Value) []reflect.Value {
+		slice := reflect.MakeSlice(sliceType, 0, len(members))
+		return []reflect.Value{reflect.Append(slice, members...)}
+	})
+
+	return constructorType, contracts.ConstructorValue(constructorValue)
+}
+
+func registerGroupMember(target *Injector, key reflect.Type, info *contracts.ObjectInfo, options []RegistrationOption) error {
+	groupKey := contracts.NewKey(reflect.SliceOf(key), "")
+	group, found := target.library.Find(groupKey, search.NoReorder)
+	memberCount := 0
+	if found {
+		memberCount = len(group.Dependencies)
+	}
+
+	memberKey := contracts.NewMemberKey(key, fmt.Sprintf("group#%d", memberCount))
+	if err := register(target, memberKey, info, options); err != nil {
+		return err
+	}
+
+	if !found {
+		group = &contracts.ObjectInfo{Lifecycle: contracts.Transient, Container: true}
+		group.ConstructorFunction = newConstructorFunction(target, group)
+		target.library.Add(groupKey, group)
+	}
+
+	group.Dependencies = append(group.Dependencies, memberKey)
+	group.ConstructorType, group.ConstructorValue = groupConstructor(groupKey.Type, len(group.Dependencies))
+	return nil
+}
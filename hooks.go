@@ -0,0 +1,160 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// Starter is implemented by singletons that must be started once the
+// dependency graph has been built, such as servers and message consumers.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by singletons that must be stopped before the
+// application exits.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// Start constructs every singleton in dependency order and starts each one,
+// either through its OnStart hook or, lacking a hook, its Start method when it
+// implements [Starter]. A singleton is only started after everything it
+// depends on has been started.
+//
+// Notes:
+//   - If any singleton fails to construct or start, every singleton started
+//     so far is stopped again in reverse order.
+//   - A hook that runs longer than its WithHookTimeout duration fails with
+//     context.DeadlineExceeded.
+//
+// Parameters:
+//   - ctx is passed to every start hook.
+//
+// Returns:
+//   - err joins the start failure with any error encountered while rolling
+//     back.
+//
+// Errors:
+//   - ErrorBadState is returned if the injector has not been verified, has
+//     been closed or has already been started.
+func (this *Injector) Start(ctx context.Context) (err error) {
+	this.lifecycleMutex.Lock()
+	defer this.lifecycleMutex.Unlock()
+
+	if err = assertValidState(this); err != nil {
+		return err
+	}
+
+	if this.started != nil {
+		return fmt.Errorf("%w: injector has already been started", ErrorBadState)
+	}
+
+	scopedStack := this.scopePool.CheckOut()
+	defer this.scopePool.CheckIn(scopedStack)
+
+	started := make([]*contracts.ObjectInfo, 0)
+	for _, key := range dependencyOrder(this) {
+		info, _ := this.library.Find(key, search.NoReorder)
+		if info.Lifecycle != contracts.Singleton {
+			continue
+		}
+
+		if _, err = get(ctx, this, key, scopedStack); err != nil {
+			return errors.Join(fmt.Errorf("constructing '%s': %w", key, err), stopAll(ctx, this, started))
+		}
+
+		if err = runHook(ctx, info, startHook(this, info)); err != nil {
+			return errors.Join(fmt.Errorf("starting '%s': %w", key, err), stopAll(ctx, this, started))
+		}
+
+		started = append(started, info)
+	}
+
+	this.started = started
+	return nil
+}
+
+// Stop stops every singleton started by [Injector.Start] in reverse start
+// order, either through its OnStop hook or, lacking a hook, its Stop method
+// when it implements [Stopper]. Every singleton is stopped even when some of
+// them fail. Stopping an injector that has not been started does nothing.
+//
+// Parameters:
+//   - ctx is passed to every stop hook.
+//
+// Returns:
+//   - err joins every error returned by the stop hooks.
+func (this *Injector) Stop(ctx context.Context) error {
+	this.lifecycleMutex.Lock()
+	defer this.lifecycleMutex.Unlock()
+
+	err := stopAll(ctx, this, this.started)
+	this.started = nil
+	return err
+}
+
+// runHook calls the hook, bounded by the registration's hook timeout if it
+// has one. A hook that outlives its timeout keeps running in the background.
+func runHook(ctx context.Context, info *contracts.ObjectInfo, hook func(ctx context.Context) error) error {
+	if hook == nil {
+		return nil
+	}
+
+	if info.HookTimeout <= 0 {
+		return hook(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, info.HookTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- hook(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func startHook(injector *Injector, info *contracts.ObjectInfo) func(ctx context.Context) error {
+	instance := unwrapInstance(info.Singleton)
+	if info.OnStart != nil {
+		return func(ctx context.Context) error { return info.OnStart(ctx, instance) }
+	}
+
+	if starter, ok := instance.(Starter); ok && instance != any(injector) {
+		return starter.Start
+	}
+
+	return nil
+}
+
+func stopAll(ctx context.Context, injector *Injector, started []*contracts.ObjectInfo) (err error) {
+	for iStarted := len(started) - 1; iStarted >= 0; iStarted-- {
+		info := started[iStarted]
+		info.Stopped = true
+		err = errors.Join(err, runHook(ctx, info, stopHook(injector, info)))
+	}
+
+	return err
+}
+
+func stopHook(injector *Injector, info *contracts.ObjectInfo) func(ctx context.Context) error {
+	instance := unwrapInstance(info.Singleton)
+	if info.OnStop != nil {
+		return func(ctx context.Context) error { return info.OnStop(ctx, instance) }
+	}
+
+	if stopper, ok := instance.(Stopper); ok && instance != any(injector) {
+		return stopper.Stop
+	}
+
+	return nil
+}
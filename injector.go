@@ -1,10 +1,14 @@
 package injector
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/smarty/injector/internal"
 	"github.com/smarty/injector/internal/contracts"
@@ -16,11 +20,19 @@ import (
 // used in applications where taking a few microseconds generating a dependency
 // is acceptable.
 type Injector struct {
+	parent            *Injector
 	library           search.Cache[contracts.KeyType, *contracts.ObjectInfo]
-	nameToKeyTrie     tries.Trie[string, reflect.Type]
+	nameToKeyTrie     tries.Trie[string, contracts.KeyType]
 	scopePool         internal.StackPool
+	singletons        []*contracts.ObjectInfo
+	singletonsMutex   sync.Mutex
+	started           []*contracts.ObjectInfo
+	lifecycleMutex    sync.Mutex
 	verificationError error
-	verified          bool
+	warnings          []error
+	captivePolicy     CaptivePolicy
+	verified          atomic.Bool
+	closed            atomic.Bool
 }
 
 // New creates a new injector, preloaded with itself.
@@ -39,7 +51,7 @@ func New(cacheStrategy ...CacheStrategy) *Injector {
 		strategy = cacheStrategy[0]
 	}
 
-	nameToKeyTrie, _ := tries.NewTrie[string, reflect.Type](func(in byte) (out byte, use bool) {
+	nameToKeyTrie, _ := tries.NewTrie[string, contracts.KeyType](func(in byte) (out byte, use bool) {
 		if (in >= 'A' && in <= 'Z') || (in >= 'a' && in <= 'z') || (in >= '0' && in <= '9') { // only alpha-numerics are considered
 			return in, true
 		}
@@ -50,10 +62,10 @@ func New(cacheStrategy ...CacheStrategy) *Injector {
 	di := &Injector{
 		library:       generateCache(strategy),
 		nameToKeyTrie: nameToKeyTrie,
-		verified:      false,
+		captivePolicy: CaptiveError,
 	}
 
-	RegisterSingleton[*Injector](di, func() *Injector { return di })
+	RegisterInstance[*Injector](di, di)
 	return di
 }
 
@@ -72,7 +84,7 @@ func New(cacheStrategy ...CacheStrategy) *Injector {
 //   - if the function provided is variadic.
 //   - if the function provided has an incongruent number of return values.
 func (this *Injector) Call(function any) (err error) {
-	_, err = this.callN(function, 0)
+	_, err = this.callN(context.Background(), function, 0)
 	return err
 }
 
@@ -94,8 +106,12 @@ func (this *Injector) Call(function any) (err error) {
 //   - if the function provided has an incongruent number of return values.
 func (this *Injector) Call1(function any) (r1 any, err error) {
 	var returns []any
-	returns, err = this.callN(function, 1)
-	return returns[0], err
+	returns, err = this.callN(context.Background(), function, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return returns[0], nil
 }
 
 // Call2 checks a function's signature then calls the function by injecting all
@@ -117,8 +133,12 @@ func (this *Injector) Call1(function any) (r1 any, err error) {
 //   - if the function provided has an incongruent number of return values.
 func (this *Injector) Call2(function any) (r1, r2 any, err error) {
 	var returns []any
-	returns, err = this.callN(function, 2)
-	return returns[0], returns[1], err
+	returns, err = this.callN(context.Background(), function, 2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return returns[0], returns[1], nil
 }
 
 // Call3 checks a function's signature then calls the function by injecting all
@@ -141,8 +161,12 @@ func (this *Injector) Call2(function any) (r1, r2 any, err error) {
 //   - if the function provided has an incongruent number of return values.
 func (this *Injector) Call3(function any) (r1, r2, r3 any, err error) {
 	var returns []any
-	returns, err = this.callN(function, 3)
-	return returns[0], returns[1], returns[2], err
+	returns, err = this.callN(context.Background(), function, 3)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return returns[0], returns[1], returns[2], nil
 }
 
 // Call4 checks a function's signature then calls the function by injecting all
@@ -166,8 +190,12 @@ func (this *Injector) Call3(function any) (r1, r2, r3 any, err error) {
 //   - if the function provided has an incongruent number of return values.
 func (this *Injector) Call4(function any) (r1, r2, r3, r4 any, err error) {
 	var returns []any
-	returns, err = this.callN(function, 4)
-	return returns[0], returns[1], returns[2], returns[3], err
+	returns, err = this.callN(context.Background(), function, 4)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return returns[0], returns[1], returns[2], returns[3], nil
 }
 
 // CallN checks a function's signature then calls the function by injecting all
@@ -186,7 +214,7 @@ func (this *Injector) Call4(function any) (r1, r2, r3, r4 any, err error) {
 //   - if the function provided is not a function.
 //   - if the function provided is variadic.
 func (this *Injector) CallN(function any) (returns []any, err error) {
-	return this.callN(function, reflect.TypeOf(function).NumOut())
+	return this.callN(context.Background(), function, reflect.TypeOf(function).NumOut())
 }
 
 // Get retrieves the given type using the registered constructor or instance.
@@ -202,26 +230,7 @@ func (this *Injector) CallN(function any) (returns []any, err error) {
 //   - if Verify() has not been called.
 //   - if Verify() returned an error.
 func (this *Injector) Get(key reflect.Type) (value any, err error) {
-	err = assertValidState(this)
-	if err != nil {
-		return nil, err
-	}
-
-	scopedStack := this.scopePool.CheckOut()
-	defer this.scopePool.CheckIn(scopedStack)
-
-	var objAsAny any
-	objAsAny, err = get(this, key, &scopedStack)
-	if err != nil {
-		return nil, err
-	}
-
-	switch o := objAsAny.(type) {
-	case reflect.Value:
-		return o.Interface(), nil
-	default:
-		return objAsAny, nil
-	}
+	return this.resolve(context.Background(), contracts.NewKey(key, ""))
 }
 
 // GetByName retrieves the named type using the registered constructor or
@@ -240,6 +249,9 @@ func (this *Injector) Get(key reflect.Type) (value any, err error) {
 //     constructor.
 //   - err is nil unless the named type cannot be found.
 //
+// Registrations made under a qualifier name are reachable by appending the
+// qualifier in square brackets, e.g. "Database[replica]".
+//
 // Errors:
 //   - ErrorNotRegistered is returned if the named type cannot be found.
 //
@@ -247,7 +259,7 @@ func (this *Injector) Get(key reflect.Type) (value any, err error) {
 //   - if Verify() has not been called.
 //   - if Verify() returned an error.
 func (this *Injector) GetByName(name string) (value any, err error) {
-	key, found := this.nameToKeyTrie.Find(name)
+	key, found := findName(this, name)
 	if !found {
 		return nil, fmt.Errorf(
 			"%w: no keys that match the string pattern %q have been registered",
@@ -256,7 +268,56 @@ func (this *Injector) GetByName(name string) (value any, err error) {
 		)
 	}
 
-	return this.Get(key)
+	return this.resolve(context.Background(), key)
+}
+
+// GetNamed retrieves the given type registered under the given qualifier name
+// using the registered constructor or instance.
+//
+// Parameters:
+//   - key is the type to look for a registered instance or constructor for.
+//   - name is the qualifier the type was registered under.
+//
+// Returns:
+//   - The registered instance or the result of the registered constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorNotRegistered is returned if no registration exists for the type
+//     under the given name.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func (this *Injector) GetNamed(key reflect.Type, name string) (value any, err error) {
+	return this.resolve(context.Background(), contracts.NewKey(key, name))
+}
+
+// RegisterInstance adds an already constructed instance for the given type.
+// Every time the type is requested, the same instance is always returned.
+//
+// Notes:
+//   - The instance is stored as a ready singleton, no constructor is ever
+//     called for it.
+//
+// Parameters:
+//   - key is the registered type that the instance will be registered with.
+//   - instance is the value returned whenever the type is requested.
+//   - options customize the registration.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when a type has already been
+//     registered.
+//   - ErrorNotAssignable is returned when the instance is nil or cannot be
+//     assigned to the key type.
+//   - ErrorNotStructOrInterface is returned when a type is not registerable.
+func (this *Injector) RegisterInstance(key reflect.Type, instance any, options ...RegistrationOption) error {
+	if instance == nil {
+		return fmt.Errorf(
+			"%w: nil instance for type '%s'",
+			ErrorNotAssignable,
+			key.Name())
+	}
+
+	return register(this, contracts.NewKey(key, ""), instanceInfo(instance), options)
 }
 
 // RegisterScope adds a constructor for the given type.
@@ -272,6 +333,7 @@ func (this *Injector) GetByName(name string) (value any, err error) {
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -286,14 +348,8 @@ func (this *Injector) GetByName(name string) (value any, err error) {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterScope(key reflect.Type, constructor any) error {
-	info := &contracts.ObjectInfo{
-		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
-		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
-		Lifecycle:        contracts.Scope,
-	}
-
-	return register(this, key, info)
+func (this *Injector) RegisterScope(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterScopeNamed(key, "", constructor, options...)
 }
 
 // RegisterScopeError adds a constructor for the given type.
@@ -308,6 +364,7 @@ func (this *Injector) RegisterScope(key reflect.Type, constructor any) error {
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -322,7 +379,49 @@ func (this *Injector) RegisterScope(key reflect.Type, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterScopeError(key reflect.Type, constructor any) error {
+func (this *Injector) RegisterScopeError(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterScopeNamedError(key, "", constructor, options...)
+}
+
+// RegisterScopeNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterScope].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterScope].
+func (this *Injector) RegisterScopeNamed(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        contracts.Scope,
+	}
+
+	return register(this, contracts.NewKey(key, name), info, options)
+}
+
+// RegisterScopeNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterScopeError].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterScopeError].
+func (this *Injector) RegisterScopeNamedError(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
 	info := &contracts.ObjectInfo{
 		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
 		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
@@ -330,7 +429,7 @@ func (this *Injector) RegisterScopeError(key reflect.Type, constructor any) erro
 		ConstructorReturnsError: true,
 	}
 
-	return register(this, key, info)
+	return register(this, contracts.NewKey(key, name), info, options)
 }
 
 // RegisterSingleton adds a constructor for the given type.
@@ -344,6 +443,7 @@ func (this *Injector) RegisterScopeError(key reflect.Type, constructor any) erro
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -358,14 +458,8 @@ func (this *Injector) RegisterScopeError(key reflect.Type, constructor any) erro
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterSingleton(key reflect.Type, constructor any) error {
-	info := &contracts.ObjectInfo{
-		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
-		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
-		Lifecycle:        contracts.Singleton,
-	}
-
-	return register(this, key, info)
+func (this *Injector) RegisterSingleton(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterSingletonNamed(key, "", constructor, options...)
 }
 
 // RegisterSingletonError adds a constructor for the given type.
@@ -378,6 +472,7 @@ func (this *Injector) RegisterSingleton(key reflect.Type, constructor any) error
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -392,7 +487,49 @@ func (this *Injector) RegisterSingleton(key reflect.Type, constructor any) error
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterSingletonError(key reflect.Type, constructor any) error {
+func (this *Injector) RegisterSingletonError(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterSingletonNamedError(key, "", constructor, options...)
+}
+
+// RegisterSingletonNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterSingleton].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingleton].
+func (this *Injector) RegisterSingletonNamed(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        contracts.Singleton,
+	}
+
+	return register(this, contracts.NewKey(key, name), info, options)
+}
+
+// RegisterSingletonNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterSingletonError].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingletonError].
+func (this *Injector) RegisterSingletonNamedError(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
 	info := &contracts.ObjectInfo{
 		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
 		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
@@ -400,7 +537,7 @@ func (this *Injector) RegisterSingletonError(key reflect.Type, constructor any)
 		ConstructorReturnsError: true,
 	}
 
-	return register(this, key, info)
+	return register(this, contracts.NewKey(key, name), info, options)
 }
 
 // RegisterTransient adds a constructor for the given type.
@@ -415,6 +552,7 @@ func (this *Injector) RegisterSingletonError(key reflect.Type, constructor any)
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -429,14 +567,8 @@ func (this *Injector) RegisterSingletonError(key reflect.Type, constructor any)
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterTransient(key reflect.Type, constructor any) error {
-	info := &contracts.ObjectInfo{
-		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
-		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
-		Lifecycle:        contracts.Transient,
-	}
-
-	return register(this, key, info)
+func (this *Injector) RegisterTransient(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterTransientNamed(key, "", constructor, options...)
 }
 
 // RegisterTransientError adds a constructor for the given type.
@@ -450,6 +582,7 @@ func (this *Injector) RegisterTransient(key reflect.Type, constructor any) error
 // Parameters:
 //   - key is the registered type that the constructor will be registered with.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -464,7 +597,49 @@ func (this *Injector) RegisterTransient(key reflect.Type, constructor any) error
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func (this *Injector) RegisterTransientError(key reflect.Type, constructor any) error {
+func (this *Injector) RegisterTransientError(key reflect.Type, constructor any, options ...RegistrationOption) error {
+	return this.RegisterTransientNamedError(key, "", constructor, options...)
+}
+
+// RegisterTransientNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterTransient].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterTransient].
+func (this *Injector) RegisterTransientNamed(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        contracts.Transient,
+	}
+
+	return register(this, contracts.NewKey(key, name), info, options)
+}
+
+// RegisterTransientNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like
+// [Injector.RegisterTransientError].
+//
+// Parameters:
+//   - key is the registered type that the constructor will be registered with.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterTransientError].
+func (this *Injector) RegisterTransientNamedError(key reflect.Type, name string, constructor any, options ...RegistrationOption) error {
 	info := &contracts.ObjectInfo{
 		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
 		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
@@ -472,7 +647,7 @@ func (this *Injector) RegisterTransientError(key reflect.Type, constructor any)
 		ConstructorReturnsError: true,
 	}
 
-	return register(this, key, info)
+	return register(this, contracts.NewKey(key, name), info, options)
 }
 
 // Call checks a function's signature then calls the function by injecting all
@@ -491,7 +666,7 @@ func (this *Injector) RegisterTransientError(key reflect.Type, constructor any)
 //   - if the function provided is variadic.
 //   - if the function provided has an incongruent number of return values.
 func Call(injector *Injector, function any) (err error) {
-	_, err = injector.callN(function, 0)
+	_, err = injector.callN(context.Background(), function, 0)
 	return err
 }
 
@@ -514,8 +689,12 @@ func Call(injector *Injector, function any) (err error) {
 //   - if the function provided has an incongruent number of return values.
 func Call1[T1 any](injector *Injector, function any) (r1 T1, err error) {
 	var returns []any
-	returns, err = injector.callN(function, 1)
-	return returns[0].(T1), err
+	returns, err = injector.callN(context.Background(), function, 1)
+	if err != nil {
+		return r1, err
+	}
+
+	return returns[0].(T1), nil
 }
 
 // Call2 checks a function's signature then calls the function by injecting all
@@ -538,8 +717,12 @@ func Call1[T1 any](injector *Injector, function any) (r1 T1, err error) {
 //   - if the function provided has an incongruent number of return values.
 func Call2[T1, T2 any](injector *Injector, function any) (r1 T1, r2 T2, err error) {
 	var returns []any
-	returns, err = injector.callN(function, 2)
-	return returns[0].(T1), returns[1].(T2), err
+	returns, err = injector.callN(context.Background(), function, 2)
+	if err != nil {
+		return r1, r2, err
+	}
+
+	return returns[0].(T1), returns[1].(T2), nil
 }
 
 // Call3 checks a function's signature then calls the function by injecting all
@@ -563,8 +746,12 @@ func Call2[T1, T2 any](injector *Injector, function any) (r1 T1, r2 T2, err erro
 //   - if the function provided has an incongruent number of return values.
 func Call3[T1, T2, T3 any](injector *Injector, function any) (r1 T1, r2 T2, r3 T3, err error) {
 	var returns []any
-	returns, err = injector.callN(function, 3)
-	return returns[0].(T1), returns[1].(T2), returns[2].(T3), err
+	returns, err = injector.callN(context.Background(), function, 3)
+	if err != nil {
+		return r1, r2, r3, err
+	}
+
+	return returns[0].(T1), returns[1].(T2), returns[2].(T3), nil
 }
 
 // Call4 checks a function's signature then calls the function by injecting all
@@ -589,8 +776,12 @@ func Call3[T1, T2, T3 any](injector *Injector, function any) (r1 T1, r2 T2, r3 T
 //   - if the function provided has an incongruent number of return values.
 func Call4[T1, T2, T3, T4 any](injector *Injector, function any) (r1 T1, r2 T2, r3 T3, r4 T4, err error) {
 	var returns []any
-	returns, err = injector.callN(function, 4)
-	return returns[0].(T1), returns[1].(T2), returns[2].(T3), returns[3].(T4), err
+	returns, err = injector.callN(context.Background(), function, 4)
+	if err != nil {
+		return r1, r2, r3, r4, err
+	}
+
+	return returns[0].(T1), returns[1].(T2), returns[2].(T3), returns[3].(T4), nil
 }
 
 // CallN checks a function's signature then calls the function by injecting all
@@ -610,7 +801,7 @@ func Call4[T1, T2, T3, T4 any](injector *Injector, function any) (r1 T1, r2 T2,
 //   - if the function provided is not a function.
 //   - if the function provided is variadic.
 func CallN(injector *Injector, function any) (returns []any, err error) {
-	return injector.callN(function, reflect.TypeOf(function).NumOut())
+	return injector.callN(context.Background(), function, reflect.TypeOf(function).NumOut())
 }
 
 // Get retrieves the given type using the registered constructor or instance.
@@ -664,6 +855,55 @@ func GetByName(injector *Injector, name string) (value any, err error) {
 	return injector.GetByName(name)
 }
 
+// GetNamed retrieves the given type registered under the given qualifier name
+// using the registered constructor or instance.
+//
+// Parameters:
+//   - injector is the dependency injector to get the instance from.
+//   - name is the qualifier the type was registered under.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorNotRegistered is returned if no registration exists for the type
+//     under the given name.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func GetNamed[Tkey any](injector *Injector, name string) (value Tkey, err error) {
+	var rawValue any
+	rawValue, err = injector.GetNamed(reflect.TypeFor[Tkey](), name)
+	if err != nil {
+		return value, err
+	}
+
+	return rawValue.(Tkey), nil
+}
+
+// RegisterInstance adds an already constructed instance for the given type.
+// Every time the type is requested, the same instance is always returned.
+//
+// Notes:
+//   - The instance is stored as a ready singleton, no constructor is ever
+//     called for it.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - instance is the value returned whenever the type is requested.
+//   - options customize the registration.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when a type has already been
+//     registered.
+//   - ErrorNotAssignable is returned when the instance is nil or cannot be
+//     assigned to the key type.
+//   - ErrorNotStructOrInterface is returned when a type is not registerable.
+func RegisterInstance[Tkey any](target *Injector, instance Tkey, options ...RegistrationOption) error {
+	return target.RegisterInstance(reflect.TypeFor[Tkey](), instance, options...)
+}
+
 // RegisterScope adds a constructor for the given type.
 // Every time the type is requested in a unique Get() call, the same instance is
 // always returned. If it's requested again in a new Get() call, the constructor
@@ -676,6 +916,7 @@ func GetByName(injector *Injector, name string) (value any, err error) {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -690,8 +931,8 @@ func GetByName(injector *Injector, name string) (value any, err error) {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterScope[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterScope(reflect.TypeFor[Tkey](), constructor)
+func RegisterScope[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterScope(reflect.TypeFor[Tkey](), constructor, options...)
 }
 
 // RegisterScopeError adds a constructor for the given type.
@@ -706,6 +947,7 @@ func RegisterScope[Tkey any](target *Injector, constructor any) error {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -720,8 +962,42 @@ func RegisterScope[Tkey any](target *Injector, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterScopeError[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterScopeError(reflect.TypeFor[Tkey](), constructor)
+func RegisterScopeError[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterScopeError(reflect.TypeFor[Tkey](), constructor, options...)
+}
+
+// RegisterScopeNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterScope.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterScope.
+func RegisterScopeNamed[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterScopeNamed(reflect.TypeFor[Tkey](), name, constructor, options...)
+}
+
+// RegisterScopeNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterScopeError.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterScopeError.
+func RegisterScopeNamedError[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterScopeNamedError(reflect.TypeFor[Tkey](), name, constructor, options...)
 }
 
 // RegisterSingleton adds a constructor for the given type.
@@ -734,6 +1010,7 @@ func RegisterScopeError[Tkey any](target *Injector, constructor any) error {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -748,8 +1025,8 @@ func RegisterScopeError[Tkey any](target *Injector, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterSingleton[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterSingleton(reflect.TypeFor[Tkey](), constructor)
+func RegisterSingleton[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterSingleton(reflect.TypeFor[Tkey](), constructor, options...)
 }
 
 // RegisterSingletonError adds a constructor for the given type.
@@ -762,6 +1039,7 @@ func RegisterSingleton[Tkey any](target *Injector, constructor any) error {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -776,8 +1054,42 @@ func RegisterSingleton[Tkey any](target *Injector, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterSingletonError[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterSingletonError(reflect.TypeFor[Tkey](), constructor)
+func RegisterSingletonError[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterSingletonError(reflect.TypeFor[Tkey](), constructor, options...)
+}
+
+// RegisterSingletonNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterSingleton.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingleton.
+func RegisterSingletonNamed[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterSingletonNamed(reflect.TypeFor[Tkey](), name, constructor, options...)
+}
+
+// RegisterSingletonNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterSingletonError.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingletonError.
+func RegisterSingletonNamedError[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterSingletonNamedError(reflect.TypeFor[Tkey](), name, constructor, options...)
 }
 
 // RegisterTransient adds a constructor for the given type.
@@ -791,6 +1103,7 @@ func RegisterSingletonError[Tkey any](target *Injector, constructor any) error {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -805,8 +1118,8 @@ func RegisterSingletonError[Tkey any](target *Injector, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterTransient[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterTransient(reflect.TypeFor[Tkey](), constructor)
+func RegisterTransient[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterTransient(reflect.TypeFor[Tkey](), constructor, options...)
 }
 
 // RegisterTransientError adds a constructor for the given type.
@@ -820,6 +1133,7 @@ func RegisterTransient[Tkey any](target *Injector, constructor any) error {
 // Parameters:
 //   - target is the Injector to register the type in.
 //   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
 //
 // Errors:
 //   - ErrorAlreadyRegistered is returned when a type has already been
@@ -834,37 +1148,245 @@ func RegisterTransient[Tkey any](target *Injector, constructor any) error {
 //     return value.
 //   - ErrorVariadicArguments is returned when a constructor has a variadic
 //     signature.
-func RegisterTransientError[Tkey any](target *Injector, constructor any) error {
-	return target.RegisterTransientError(reflect.TypeFor[Tkey](), constructor)
+func RegisterTransientError[Tkey any](target *Injector, constructor any, options ...RegistrationOption) error {
+	return target.RegisterTransientError(reflect.TypeFor[Tkey](), constructor, options...)
+}
+
+// RegisterTransientNamed adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterTransient.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterTransient.
+func RegisterTransientNamed[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterTransientNamed(reflect.TypeFor[Tkey](), name, constructor, options...)
+}
+
+// RegisterTransientNamedError adds a constructor for the given type under the given
+// qualifier name, allowing several registrations of the same type to exist
+// side by side. Apart from the name, it behaves exactly like RegisterTransientError.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - name is the qualifier that distinguishes this registration. An empty
+//     name is the same as the unnamed registration.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterTransientError.
+func RegisterTransientNamedError[Tkey any](target *Injector, name string, constructor any, options ...RegistrationOption) error {
+	return target.RegisterTransientNamedError(reflect.TypeFor[Tkey](), name, constructor, options...)
 }
 
 // Verify examines all registered types and their corresponding constructors
-// and validates them, otherwise an error is returned.
+// and validates them, otherwise an error is returned. Once validated, every
+// singleton registered with the Eager option is constructed.
 //
 // Parameters:
 //   - target is the Injector to explore and verify all the registered types in.
 //
+// Returns:
+//   - err is a *VerificationError listing every problem found in the graph,
+//     each followed by its dependency path, so that all of them can be fixed
+//     at once. Each problem is reported once, however many registrations
+//     reach it.
+//
 // Errors:
+//   - ErrorBadState is returned, instead of a *VerificationError, when the
+//     injector is a child whose parent has been closed, has not been
+//     verified or failed its verification.
+//   - ErrorCaptiveDependency indicates that a singleton depends on a
+//     registration with a shorter lifecycle, see SetCaptivePolicy.
 //   - ErrorDependencyLoop indicates that an unsolvable dependency injection
 //     loop.
+//   - ErrorDuplicateMapKey indicates that more than one map entry has been
+//     contributed under the same key.
 //   - ErrorNotRegistered indicates that a required dependency does not appear
 //     in the registered list.
+//   - any error returned by the constructor of an Eager singleton, followed by
+//     the dependency path that led to it.
 func Verify(injector *Injector) error {
-	injector.verified = false
+	injector.verified.Store(false)
 	injector.verificationError = nil
-	injector.library.Prepare()
-	for key := range injector.library.All() {
-		if err := verify(injector, key); err != nil {
+	if injector.parent != nil {
+		if err := assertValidState(injector.parent); err != nil {
+			err = fmt.Errorf("%w: child injector cannot resolve through its parent", err)
 			injector.verificationError = err
 			return err
 		}
 	}
 
-	injector.verified = true
+	injector.library.Prepare()
+
+	verification := newVerification(injector)
+	for _, key := range sortedKeys(injector) {
+		verify(verification, key)
+	}
+
+	sortErrors(verification.warnings)
+	injector.warnings = verification.warnings
+	if len(verification.problems) > 0 {
+		sortErrors(verification.problems)
+
+		err := &VerificationError{Problems: verification.problems}
+		injector.verificationError = err
+		return err
+	}
+
+	injector.verified.Store(true)
+	if err := build(injector, true); err != nil {
+		injector.verified.Store(false)
+		injector.verificationError = err
+		return err
+	}
+
+	return nil
+}
+
+// VerifyAndBuild verifies the injector, see Verify, and then constructs every
+// registered singleton in dependency order, so that a failing constructor is
+// discovered at boot rather than on the first request.
+//
+// Notes:
+//   - Constructor errors leave the injector in a bad state, exactly like a
+//     failed Verify.
+//   - A singleton depending on a singleton that failed to construct is not
+//     constructed, so every failure is reported once.
+//
+// Parameters:
+//   - target is the Injector to verify and build.
+//
+// Returns:
+//   - err joins every constructor error, each followed by the dependency path
+//     that led to the failing constructor.
+//
+// Errors:
+//   - the same errors as Verify.
+func VerifyAndBuild(injector *Injector) error {
+	if err := Verify(injector); err != nil {
+		return err
+	}
+
+	if err := build(injector, false); err != nil {
+		injector.verified.Store(false)
+		injector.verificationError = err
+		return err
+	}
+
+	return nil
+}
+
+func (this *Injector) callN(ctx context.Context, function any, expectedReturnCount int) (returns []any, err error) {
+	scopedStack := this.scopePool.CheckOut()
+	defer this.scopePool.CheckIn(scopedStack)
+
+	return call(ctx, this, function, expectedReturnCount, scopedStack)
+}
+
+func (this *Injector) resolve(ctx context.Context, key contracts.KeyType) (value any, err error) {
+	scopedStack := this.scopePool.CheckOut()
+	defer this.scopePool.CheckIn(scopedStack)
+
+	return resolve(ctx, this, key, scopedStack)
+}
+
+// applyOptions derives the dependencies from the constructor's parameters,
+// applies the registration options and generates the constructor function.
+func applyOptions(target *Injector, key contracts.KeyType, info *contracts.ObjectInfo, options []RegistrationOption) error {
+	info.Dependencies = make([]contracts.KeyType, info.ConstructorType.NumIn())
+	info.Deferred = make([]bool, info.ConstructorType.NumIn())
+	info.Optional = make([]bool, info.ConstructorType.NumIn())
+	for iParameter := range info.Dependencies {
+		info.Dependencies[iParameter], info.Deferred[iParameter], info.Optional[iParameter] = dependencyKey(info.ConstructorType.In(iParameter), "")
+	}
+
+	for _, option := range options {
+		if err := option(info); err != nil {
+			return fmt.Errorf("%w: constructor for type '%s'", err, key.Name())
+		}
+	}
+
+	info.ConstructorFunction = newConstructorFunction(target, info)
+	return nil
+}
+
+// assertOpen only checks that the injector has not been closed. Unlike Get,
+// the Call family does not require a verified injector.
+func assertOpen(injector *Injector) (err error) {
+	if injector.closed.Load() {
+		return fmt.Errorf(
+			"%w: injector has been closed",
+			ErrorBadState)
+	}
+
 	return nil
 }
 
-func (this *Injector) callN(function any, expectedReturnCount int) (returns []any, err error) {
+func assertValidState(injector *Injector) (err error) {
+	if err = assertOpen(injector); err != nil {
+		return err
+	}
+
+	if !injector.verified.Load() {
+		if injector.verificationError != nil {
+			return fmt.Errorf(
+				"%w: injector is in a bad state with verification error: %w",
+				ErrorBadState,
+				injector.verificationError)
+		}
+
+		return fmt.Errorf(
+			"%w: injector is not in a verified state, call Verify() on injector after registering all types",
+			ErrorBadState)
+	}
+
+	return nil
+}
+
+// build constructs the singletons in dependency order, skipping any
+// registration whose dependencies already failed to construct.
+func build(injector *Injector, eagerOnly bool) (err error) {
+	scopedStack := injector.scopePool.CheckOut()
+	defer injector.scopePool.CheckIn(scopedStack)
+
+	failed := make(map[contracts.KeyType]struct{})
+	for _, key := range dependencyOrder(injector) {
+		info, _ := injector.library.Find(key, search.NoReorder)
+		if slices.ContainsFunc(info.Dependencies, func(dependency contracts.KeyType) bool {
+			_, dependencyFailed := failed[dependency]
+			return dependencyFailed
+		}) {
+			failed[key] = struct{}{}
+			continue
+		}
+
+		if info.Lifecycle != contracts.Singleton || (eagerOnly && !info.Eager) {
+			continue
+		}
+
+		if _, e := get(context.Background(), injector, key, scopedStack); e != nil {
+			failed[key] = struct{}{}
+			err = errors.Join(err, e)
+		}
+	}
+
+	return err
+}
+
+func call(ctx context.Context, injector *Injector, function any, expectedReturnCount int, scoped *contracts.ScopedInstances) (returns []any, err error) {
+	err = assertOpen(injector)
+	if err != nil {
+		return nil, err
+	}
+
 	functionType := reflect.TypeOf(function)
 	functionValue := reflect.ValueOf(function)
 	if functionType.Kind() != reflect.Func {
@@ -888,31 +1410,26 @@ func (this *Injector) callN(function any, expectedReturnCount int) (returns []an
 
 	parameterCount := functionType.NumIn()
 	values := make([]reflect.Value, parameterCount)
-	parametersInfo := make([]contracts.ConstructorType, parameterCount)
 	for iParameter := 0; iParameter < parameterCount; iParameter++ {
-		parametersInfo[iParameter] = functionType.In(iParameter)
-	}
-
-	scopedStack := this.scopePool.CheckOut()
-	defer this.scopePool.CheckIn(scopedStack)
-
-	returnValues := func(scopedList *[]contracts.ScopedInstance) []reflect.Value {
-		for iParameter := 0; iParameter < parameterCount; iParameter++ {
-			rawValue, e := get(this, parametersInfo[iParameter], scopedList)
-			if e != nil {
-				err = errors.Join(err, e)
-			}
-
-			values[iParameter] = rawValue.(reflect.Value)
+		key, isDeferred, isOptional := dependencyKey(functionType.In(iParameter), "")
+		value, e := parameterValue(ctx, injector, functionType.In(iParameter), key, isDeferred, isOptional, scoped)
+		if e != nil {
+			err = errors.Join(err, e)
+			continue
 		}
 
-		return reflect.Value(functionValue).Call(values)
-	}(&scopedStack)
+		values[iParameter] = value
+	}
 
 	if err != nil {
 		return nil, err
 	}
 
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	returnValues := functionValue.Call(values)
 	toReturn := make([]any, len(returnValues))
 	for iReturn := range returnValues {
 		toReturn[iReturn] = returnValues[iReturn].Interface()
@@ -921,79 +1438,203 @@ func (this *Injector) callN(function any, expectedReturnCount int) (returns []an
 	return toReturn, nil
 }
 
-func assertValidState(injector *Injector) (err error) {
-	if !injector.verified {
-		if injector.verificationError != nil {
-			return fmt.Errorf(
-				"%w: injector is in a bad state with verification error: %w",
-				ErrorBadState,
-				injector.verificationError)
+func compareKeys(left, right contracts.KeyType) int {
+	if order := strings.Compare(left.String(), right.String()); order != 0 {
+		return order
+	}
+
+	return strings.Compare(left.Type.PkgPath(), right.Type.PkgPath())
+}
+
+// dependencyKey is the key a parameter of the given type depends on: the
+// parameter type itself, or the type wrapped by a Lazy, Provider or Optional.
+// Pointers to the wrappers are plain types, as their methods cannot be called
+// on a nil pointer.
+func dependencyKey(parameterType reflect.Type, qualifier string) (key contracts.KeyType, isDeferred, isOptional bool) {
+	switch {
+	case parameterType.Kind() == reflect.Func && parameterType.Implements(reflect.TypeFor[deferred]()):
+		return contracts.NewKey(reflect.Zero(parameterType).Interface().(deferred).deferredType(), qualifier), true, false
+	case isOptionalType(parameterType):
+		return contracts.NewKey(reflect.Zero(parameterType).Interface().(optional).optionalType(), qualifier), false, true
+	default:
+		return contracts.NewKey(parameterType, qualifier), false, false
+	}
+}
+
+// dependencyOrder lists every registered key so that each key comes after all
+// of its dependencies. Keys are visited in sorted order, making the result
+// deterministic regardless of the caching strategy.
+func dependencyOrder(injector *Injector) []contracts.KeyType {
+	keys := sortedKeys(injector)
+	visited := make(map[contracts.KeyType]bool, len(keys))
+	ordered := make([]contracts.KeyType, 0, len(keys))
+	var visit func(key contracts.KeyType)
+	visit = func(key contracts.KeyType) {
+		if visited[key] {
+			return
 		}
 
-		return fmt.Errorf(
-			"%w: injector is not in a verified state, call Verify() on injector after registering all types",
-			ErrorBadState)
+		visited[key] = true
+		info, found := injector.library.Find(key, search.NoReorder)
+		if !found {
+			return
+		}
+
+		for _, dependency := range info.Dependencies {
+			visit(dependency)
+		}
+
+		ordered = append(ordered, key)
 	}
 
-	return nil
+	for _, key := range keys {
+		visit(key)
+	}
+
+	return ordered
 }
 
-func get(injector *Injector, key contracts.KeyType, scoped *[]contracts.ScopedInstance) (returnValue any, err error) {
+func get(ctx context.Context, injector *Injector, key contracts.KeyType, scoped *contracts.ScopedInstances) (returnValue any, err error) {
 	info, found := injector.library.Find(key, search.Reorder)
+	if !found && injector.parent != nil {
+		if err = assertValidState(injector.parent); err != nil {
+			return nil, withPath(key, fmt.Errorf("%w: type '%s' is resolved through a parent injector", err, key.String()))
+		}
+
+		return get(ctx, injector.parent, key, scoped)
+	}
+
 	if !found {
-		return nil, fmt.Errorf("%w: type '%s'", ErrorNotRegistered, key.Name())
+		return nil, withPath(key, &NotRegisteredError{Key: key})
 	}
 
 	switch info.Lifecycle {
 	case contracts.Scope:
-		for _, scopedItem := range *scoped {
-			if scopedItem.Type == key {
-				return scopedItem.Value, nil
-			}
+		if value, found := scoped.Find(info); found {
+			return value, nil
 		}
 
-		if info.ConstructorFunction != nil {
-			obj, e := info.ConstructorFunction(scoped)
-			if e != nil {
-				return nil, e
-			}
-
-			*scoped = append(*scoped, contracts.ScopedInstance{Type: key, Value: obj})
-			return obj, nil
+		obj, e := info.ConstructorFunction(ctx, scoped)
+		if e != nil {
+			return nil, withPath(key, e)
 		}
+
+		return scoped.Add(contracts.ScopedInstance{Type: key, Info: info, Value: obj}), nil
 	case contracts.Singleton:
-		if info.Singleton != nil {
-			return info.Singleton, nil
-		}
-	case contracts.Transient:
-		if info.ConstructorFunction != nil {
-			return info.ConstructorFunction(scoped)
-		}
+		returnValue, err = getSingleton(ctx, injector, info, scoped)
+	default:
+		returnValue, err = info.ConstructorFunction(ctx, scoped)
 	}
 
-	parameterCount := info.ConstructorType.NumIn()
-	values := make([]reflect.Value, parameterCount)
-	parametersInfo := make([]contracts.ConstructorType, parameterCount)
-	for iParameter := 0; iParameter < parameterCount; iParameter++ {
-		parametersInfo[iParameter] = info.ConstructorType.In(iParameter)
+	if err != nil {
+		return nil, withPath(key, err)
+	}
+
+	return returnValue, nil
+}
+
+// getSingleton constructs the singleton exactly once, even under concurrent
+// calls. A constructor error is returned without being cached, so the next
+// call tries again. Singletons built from an external instance are not owned
+// by the injector, so they are never disposed of. A singleton outlives any
+// Scope, so it is never constructed with the scoped instances of one.
+func getSingleton(ctx context.Context, injector *Injector, info *contracts.ObjectInfo, scoped *contracts.ScopedInstances) (returnValue any, err error) {
+	info.Mutex.Lock()
+	defer info.Mutex.Unlock()
+
+	if info.Singleton != nil {
+		return info.Singleton, nil
 	}
 
-	info.ConstructorFunction = func(scopedList *[]contracts.ScopedInstance) (value any, err error) {
-		for iParameter := 0; iParameter < parameterCount; iParameter++ {
-			var rawValue any
-			rawValue, err = get(injector, parametersInfo[iParameter], scopedList)
+	if scoped.Owner != nil {
+		scoped = injector.scopePool.CheckOut()
+		defer injector.scopePool.CheckIn(scoped)
+	}
+
+	obj, e := info.ConstructorFunction(ctx, scoped)
+	if e != nil {
+		return nil, e
+	}
+
+	info.Singleton = obj
+	if info.External {
+		return obj, nil
+	}
+
+	injector.singletonsMutex.Lock()
+	injector.singletons = append(injector.singletons, info)
+	injector.singletonsMutex.Unlock()
+	return obj, nil
+}
+
+// instanceInfo describes an already constructed instance as a singleton whose
+// constructor returns the instance.
+func instanceInfo(instance any) *contracts.ObjectInfo {
+	value := reflect.ValueOf(instance)
+	constructorType := reflect.FuncOf(nil, []reflect.Type{value.Type()}, false)
+	return &contracts.ObjectInfo{
+		ConstructorType: contracts.ConstructorType(constructorType),
+		ConstructorValue: contracts.ConstructorValue(reflect.MakeFunc(constructorType, func([]reflect.Value) []reflect.Value {
+			return []reflect.Value{value}
+		})),
+		Lifecycle: contracts.Singleton,
+		Singleton: value,
+		External:  true,
+	}
+}
+
+// isOptional reports whether the dependency at the given index may be left
+// unregistered, in which case its zero value is injected.
+func isOptional(info *contracts.ObjectInfo, index int) bool {
+	return index < len(info.Optional) && info.Optional[index]
+}
+
+func isStructLike(key reflect.Type) bool {
+	return key.Kind() == reflect.Struct || key.Kind() == reflect.Interface
+}
+
+// loopIdentity names a loop by its members, independently of where the walk
+// entered it.
+func loopIdentity(loop []contracts.KeyType) string {
+	names := make([]string, len(loop))
+	for iKey, key := range loop {
+		names[iKey] = key.String()
+	}
+
+	slices.Sort(names)
+	return strings.Join(slices.Compact(names), " ")
+}
+
+// newConstructorFunction generates the function that resolves every
+// dependency of the registration and calls its constructor. The registration's
+// fields are read on every call, so decorating a registration or adding
+// members to a group needs no new function.
+func newConstructorFunction(injector *Injector, info *contracts.ObjectInfo) func(context.Context, *contracts.ScopedInstances) (any, error) {
+	return func(ctx context.Context, scopedList *contracts.ScopedInstances) (value any, err error) {
+		values := make([]reflect.Value, len(info.Dependencies))
+		for iParameter, dependency := range info.Dependencies {
+			values[iParameter], err = parameterValue(
+				ctx,
+				injector,
+				info.ConstructorType.In(iParameter),
+				dependency,
+				isDeferred(info, iParameter),
+				isOptional(info, iParameter),
+				scopedList)
 			if err != nil {
 				return nil, err
 			}
+		}
 
-			values[iParameter] = rawValue.(reflect.Value)
+		if err = ctx.Err(); err != nil {
+			return nil, err
 		}
 
 		returns := reflect.Value(info.ConstructorValue).Call(values)
 		if info.ConstructorReturnsError {
 			errorRaw := returns[1].Interface()
 			if errorRaw != nil {
-				return returns[0], errorRaw.(error)
+				return returns[0], &ConstructorError{Err: errorRaw.(error)}
 			}
 
 			return returns[0], nil
@@ -1001,29 +1642,132 @@ func get(injector *Injector, key contracts.KeyType, scoped *[]contracts.ScopedIn
 
 		return returns[0], nil
 	}
+}
 
-	obj, e := info.ConstructorFunction(scoped)
-	if e != nil {
-		return nil, e
+// parameterValue resolves the value passed for a parameter of the given type:
+// the context of the resolution for a context.Context, a Lazy or Provider for
+// deferred parameters, the zero value for optional parameters whose key is
+// not registered, and otherwise the resolved value, wrapped when the
+// parameter is an Optional.
+func parameterValue(ctx context.Context, injector *Injector, parameterType reflect.Type, key contracts.KeyType, isDeferred, isOptional bool, scoped *contracts.ScopedInstances) (reflect.Value, error) {
+	if isContextKey(key) {
+		return reflect.ValueOf(&ctx).Elem(), nil
 	}
 
-	switch info.Lifecycle {
-	case contracts.Scope:
-		*scoped = append(*scoped, contracts.ScopedInstance{Type: key, Value: obj})
-	case contracts.Singleton:
-		info.Singleton = obj
+	if isOptional {
+		if _, _, found := find(injector, key); !found {
+			return reflect.Zero(parameterType), nil
+		}
 	}
 
-	return obj, nil
+	if isDeferred {
+		return deferredValue(injector, parameterType, key, scoped), nil
+	}
+
+	rawValue, err := get(ctx, injector, key, scoped)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	value := rawValue.(reflect.Value)
+	if isOptionalType(parameterType) {
+		return presentValue(parameterType, value), nil
+	}
+
+	return value, nil
 }
 
-func isStructLike(key contracts.KeyType) bool {
-	return key.Kind() == reflect.Struct || key.Kind() == reflect.Interface
+func register(target *Injector, key contracts.KeyType, info *contracts.ObjectInfo, options []RegistrationOption) error {
+	if err := validateConstructor(key, info); err != nil {
+		return err
+	}
+
+	return store(target, key, info, options)
 }
 
-func register(target *Injector, key reflect.Type, info *contracts.ObjectInfo) error {
-	target.verified = false
-	if !isStructLike(key) && !validPointerKey(key) {
+func resolve(ctx context.Context, injector *Injector, key contracts.KeyType, scoped *contracts.ScopedInstances) (value any, err error) {
+	err = assertValidState(injector)
+	if err != nil {
+		return nil, err
+	}
+
+	var objAsAny any
+	objAsAny, err = get(ctx, injector, key, scoped)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o := objAsAny.(type) {
+	case reflect.Value:
+		return o.Interface(), nil
+	default:
+		return objAsAny, nil
+	}
+}
+
+// sortedKeys lists every registered key in a deterministic order. The keys are
+// collected before anything is looked up, as some caching strategies hold a
+// lock while iterating.
+func sortedKeys(injector *Injector) []contracts.KeyType {
+	keys := make([]contracts.KeyType, 0)
+	for key := range injector.library.All() {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, compareKeys)
+	return keys
+}
+
+func sortErrors(errs []error) {
+	slices.SortFunc(errs, func(left, right error) int {
+		return strings.Compare(left.Error(), right.Error())
+	})
+}
+
+// store adds a validated registration to the library, unless the key has
+// already been registered. Group members and map entries are only reachable
+// through their group or map, so they are kept out of the name trie.
+func store(target *Injector, key contracts.KeyType, info *contracts.ObjectInfo, options []RegistrationOption) error {
+	target.verified.Store(false)
+	if _, ok := target.library.Find(key, search.Reorder); ok {
+		return fmt.Errorf(
+			"%w: constructor for type '%s'",
+			ErrorAlreadyRegistered,
+			key.Name())
+	}
+
+	if err := applyOptions(target, key, info, options); err != nil {
+		return err
+	}
+
+	if key.Member == "" {
+		info.Name = trieName(key)
+		target.nameToKeyTrie.Add(info.Name, key)
+	}
+
+	target.library.Add(key, info)
+	return nil
+}
+
+// trieName is the name a key is reachable under through GetByName: the type
+// name without package or pointer symbols, followed by the qualifier (if any).
+// An unnamed function type, registered as a factory, keeps its full signature.
+func trieName(key contracts.KeyType) string {
+	name := key.Type.String()
+	if key.Type.Kind() != reflect.Func || key.Type.Name() != "" {
+		nameParts := strings.Split(name, ".")
+		name = nameParts[len(nameParts)-1]
+	}
+
+	if key.Qualifier == "" {
+		return name
+	}
+
+	return name + "[" + key.Qualifier + "]"
+}
+
+func validateConstructor(key contracts.KeyType, info *contracts.ObjectInfo) error {
+	if !isStructLike(key.Type) && !validPointerKey(key.Type) {
 		return fmt.Errorf(
 			"%w: type '%s'",
 			ErrorNotStructOrInterface,
@@ -1060,7 +1804,7 @@ func register(target *Injector, key reflect.Type, info *contracts.ObjectInfo) er
 		}
 	}
 
-	if !info.ConstructorType.Out(0).AssignableTo(key) {
+	if !info.ConstructorType.Out(0).AssignableTo(key.Type) {
 		return fmt.Errorf(
 			"%w: constructor's return type '%s' is not assignable to type '%s'",
 			ErrorNotAssignable,
@@ -1075,20 +1819,10 @@ func register(target *Injector, key reflect.Type, info *contracts.ObjectInfo) er
 			key.Name())
 	}
 
-	if _, ok := target.library.Find(key, search.Reorder); ok {
-		return fmt.Errorf(
-			"%w: constructor for type '%s'",
-			ErrorAlreadyRegistered,
-			key.Name())
-	}
-
-	nameParts := strings.Split(key.String(), ".")
-	target.nameToKeyTrie.Add(nameParts[len(nameParts)-1], key)
-	target.library.Add(key, info)
 	return nil
 }
 
-func validPointerKey(key contracts.KeyType) bool {
+func validPointerKey(key reflect.Type) bool {
 	keyKind := key.Kind()
 	if keyKind != reflect.Pointer {
 		return false
@@ -1102,53 +1836,111 @@ func validPointerKey(key contracts.KeyType) bool {
 	return false
 }
 
-func verify(injector *Injector, key contracts.KeyType) error {
-	info, _ := injector.library.Find(key, search.NoReorder)
-	stack := make([]contracts.ConstructorType, 0)
-	stack = append(stack, info.ConstructorType)
-	err := verifyStack(injector, &stack)
-	if err != nil {
-		sb := &strings.Builder{}
-		for iRequirement, requirement := range stack {
-			if iRequirement > 0 {
-				sb.WriteString(" -> ")
-			}
+// verification collects the problems found while walking the graph. Keys are
+// remembered once their whole subgraph has been walked, so every registration
+// is walked once no matter how many roots depend on it.
+type verification struct {
+	injector *Injector
+	problems []error
+	warnings []error
+	reported map[string]struct{}
+	walked   map[contracts.KeyType]struct{}
+	onStack  map[contracts.KeyType]int
+}
 
-			sb.WriteString(requirement.Name())
-		}
+func newVerification(injector *Injector) *verification {
+	return &verification{
+		injector: injector,
+		reported: make(map[string]struct{}),
+		walked:   make(map[contracts.KeyType]struct{}),
+		onStack:  make(map[contracts.KeyType]int),
+	}
+}
 
-		return fmt.Errorf("%w\n\t%s", err, sb.String())
+// report records the problem unless a problem with the same identity has
+// already been recorded.
+func (this *verification) report(identity string, err error) {
+	if _, ok := this.reported[identity]; ok {
+		return
 	}
 
-	return nil
+	this.reported[identity] = struct{}{}
+	this.problems = append(this.problems, err)
 }
 
-func verifyStack(injector *Injector, stack *[]contracts.ConstructorType) error {
-	focus := (*stack)[len(*stack)-1]
-	parameterCount := focus.NumIn()
-	for iParameter := 0; iParameter < parameterCount; iParameter++ {
-		parameterType := focus.In(iParameter)
-		parameterInfo, ok := injector.library.Find(parameterType, search.NoReorder)
+// warn records the warning unless a problem with the same identity has
+// already been recorded.
+func (this *verification) warn(identity string, err error) {
+	if _, ok := this.reported[identity]; ok {
+		return
+	}
+
+	this.reported[identity] = struct{}{}
+	this.warnings = append(this.warnings, err)
+}
+
+func verify(verification *verification, key contracts.KeyType) {
+	info, _ := verification.injector.library.Find(key, search.NoReorder)
+	if err := verifyMapKeys(key, info); err != nil {
+		verification.report("map "+key.String(), err)
+	}
+
+	verifyCaptives(verification, key, info)
+
+	if _, ok := verification.walked[key]; ok {
+		return
+	}
+
+	stack := []contracts.KeyType{key}
+	verification.onStack[key] = 0
+	verifyStack(verification, &stack)
+	delete(verification.onStack, key)
+}
+
+// verifyStack walks every dependency of the key on top of the stack, reporting
+// each missing dependency and loop rather than stopping at the first one. A
+// loop is a dependency on a key that is still on the stack. Dependencies
+// provided by a parent injector are verified by the parent, and deferred
+// dependencies are verified on their own, so loops through them are allowed.
+func verifyStack(verification *verification, stack *[]contracts.KeyType) {
+	library := verification.injector.library
+	focusKey := (*stack)[len(*stack)-1]
+	focus, _ := library.Find(focusKey, search.NoReorder)
+	for iParameter, parameterKey := range focus.Dependencies {
+		if _, ok := verification.walked[parameterKey]; ok {
+			continue
+		}
+
+		_, owner, ok := find(verification.injector, parameterKey)
+		if !ok && (isOptional(focus, iParameter) || isContextKey(parameterKey)) {
+			continue
+		}
+
 		if !ok {
-			return fmt.Errorf(
-				"%w: constructor for type '%s'",
-				ErrorNotRegistered,
-				parameterType.Name())
+			verification.report(
+				"missing "+focusKey.String()+" "+parameterKey.String(),
+				&NotRegisteredError{Key: parameterKey, Requester: focusKey, Path: append(slices.Clone(*stack), parameterKey)})
+			continue
 		}
 
-		for _, requirement := range *stack {
-			if requirement == parameterInfo.ConstructorType {
-				return ErrorDependencyLoop
-			}
+		if owner != verification.injector || isDeferred(focus, iParameter) {
+			continue
 		}
 
-		*stack = append(*stack, parameterInfo.ConstructorType)
-		err := verifyStack(injector, stack)
-		if err != nil {
-			return err
+		if loopStart, ok := verification.onStack[parameterKey]; ok {
+			path := append(slices.Clone(*stack), parameterKey)
+			verification.report(
+				"loop "+loopIdentity(path[loopStart:]),
+				&DependencyLoopError{Key: parameterKey, Requester: focusKey, Path: path})
+			continue
 		}
+
+		verification.onStack[parameterKey] = len(*stack)
+		*stack = append(*stack, parameterKey)
+		verifyStack(verification, stack)
+		*stack = (*stack)[:len(*stack)-1]
+		delete(verification.onStack, parameterKey)
 	}
 
-	*stack = (*stack)[:len(*stack)-1]
-	return nil
+	verification.walked[focusKey] = struct{}{}
 }
@@ -1,9 +1,17 @@
 package injector
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/smarty/assertions/should"
 	"github.com/smarty/gunit"
@@ -55,6 +63,189 @@ func (this *InjectorFixture) TestDependencyLoop() {
 	this.So(err, should.Wrap, ErrorDependencyLoop)
 }
 
+func (this *InjectorFixture) TestVerify_ReportsEveryProblemOnce() {
+	for _, strategy := range []CacheStrategy{Map, BubbleList, PriorityList} {
+		di := New(strategy)
+		err := RegisterSingleton[Car](di, NewRegularCar)
+		this.So(err, should.BeNil)
+		err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+		this.So(err, should.BeNil)
+		err = RegisterMapEntry[Driver](di, "regular", TransientLifecycle, NewRegularDriver)
+		this.So(err, should.BeNil)
+		err = RegisterMapEntry[Driver](di, "regular", TransientLifecycle, NewRegularDriver)
+		this.So(err, should.BeNil)
+
+		err = Verify(di)
+
+		var verificationError *VerificationError
+		this.So(errors.As(err, &verificationError), should.BeTrue)
+		this.So(verificationError.Problems, should.HaveLength, 3)
+		this.So(err, should.Wrap, ErrorNotRegistered)
+		this.So(err, should.Wrap, ErrorDuplicateMapKey)
+		this.So(err.Error(), should.ContainSubstring, "test.Car -> test.Driver")
+		this.So(err.Error(), should.ContainSubstring, "test.CounterWrapper -> test.Counter")
+		this.So(Verify(di).Error(), should.Equal, err.Error())
+	}
+}
+
+func (this *InjectorFixture) TestTypedErrors_NotRegistered() {
+	di := New()
+	err := RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+
+	var notRegistered *NotRegisteredError
+	this.So(errors.As(Verify(di), &notRegistered), should.BeTrue)
+	this.So(notRegistered.Key.Type, should.Equal, reflect.TypeFor[Driver]())
+	this.So(notRegistered.Requester.Type, should.Equal, reflect.TypeFor[Car]())
+	this.So(notRegistered.Path, should.Equal, []Key{{Type: reflect.TypeFor[Car]()}, {Type: reflect.TypeFor[Driver]()}})
+
+	di = New()
+	this.So(Verify(di), should.BeNil)
+	_, err = Get[Driver](di)
+	this.So(errors.As(err, &notRegistered), should.BeTrue)
+	this.So(notRegistered.Requester.Type, should.BeNil)
+	this.So(notRegistered.Path, should.Equal, []Key{{Type: reflect.TypeFor[Driver]()}})
+}
+
+func (this *InjectorFixture) TestTypedErrors_DependencyLoop() {
+	di := New()
+	err := RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Driver](di, NewLoopDriver)
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+
+	var loop *DependencyLoopError
+	this.So(errors.As(err, &loop), should.BeTrue)
+	this.So(err.(*VerificationError).Problems, should.HaveLength, 1)
+	this.So(loop.Key.Type, should.Equal, reflect.TypeFor[Car]())
+	this.So(loop.Requester.Type, should.Equal, reflect.TypeFor[Driver]())
+	this.So(loop.Path, should.HaveLength, 3)
+}
+
+func (this *InjectorFixture) TestTypedErrors_Constructor() {
+	boom := errors.New("boom")
+	di := New()
+	err := RegisterTransientError[Driver](di, func() (Driver, error) { return nil, boom })
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	_, err = Get[Car](di)
+
+	var constructorError *ConstructorError
+	this.So(errors.As(err, &constructorError), should.BeTrue)
+	this.So(err, should.Wrap, ErrorConstructor)
+	this.So(err, should.Wrap, boom)
+	this.So(constructorError.Key.Type, should.Equal, reflect.TypeFor[Driver]())
+	this.So(constructorError.Path, should.Equal, []Key{{Type: reflect.TypeFor[Car]()}, {Type: reflect.TypeFor[Driver]()}})
+}
+
+func (this *InjectorFixture) TestDependencyLoop_SharedConstructorSignatureIsNotALoop() {
+	di := New()
+	err := RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Driver](di, NewLoopDriver, WithNamedParameter(0, "backup"))
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[Car](di, "backup", NewRegularCar, WithNamedParameter(0, "plain"))
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[Driver](di, "plain", NewRegularDriver)
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+
+	this.So(err, should.BeNil)
+	this.So(skipError(Get[Car](di)).GetDriver().GetName(), should.Equal, "Lupin")
+}
+
+func (this *InjectorFixture) TestVerify_WalksSharedSubgraphsOnce() {
+	const depth = 64
+	di := New()
+	err := RegisterSingletonNamed[Car](di, strconv.Itoa(depth), func() Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+	for level := depth - 1; level >= 0; level-- {
+		next := strconv.Itoa(level + 1)
+		err = RegisterSingletonNamed[Car](di, strconv.Itoa(level), func(left, right Car) Car { return left },
+			WithNamedParameter(0, next), WithNamedParameter(1, next))
+		this.So(err, should.BeNil)
+	}
+
+	this.So(Verify(di), should.BeNil)
+}
+
+func (this *InjectorFixture) TestCaptiveDependency_SingletonCapturingScope() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, func(wrapper CounterWrapper) Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+
+	var captive *CaptiveDependencyError
+	this.So(errors.As(err, &captive), should.BeTrue)
+	this.So(err, should.Wrap, ErrorCaptiveDependency)
+	this.So(captive.Key.Type, should.Equal, reflect.TypeFor[Counter]())
+	this.So(captive.Lifecycle, should.Equal, ScopeLifecycle)
+	this.So(captive.Requester.Type, should.Equal, reflect.TypeFor[Car]())
+	this.So(captive.Path, should.HaveLength, 3)
+}
+
+func (this *InjectorFixture) TestCaptiveDependency_StrictReportsTransients() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	err = di.SetCaptivePolicy(CaptiveStrict)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorCaptiveDependency)
+
+	err = di.SetCaptivePolicy(CaptivePolicy(42))
+	this.So(err, should.Wrap, ErrorInvalidOption)
+}
+
+func (this *InjectorFixture) TestCaptiveDependency_StrictWalksThroughContainers() {
+	di := New()
+	err := di.SetCaptivePolicy(CaptiveStrict)
+	this.So(err, should.BeNil)
+	err = RegisterGroupMember[Driver](di, SingletonLifecycle, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[*CallCounter](di, func(drivers []Driver) *CallCounter { return NewCallCounter() })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorCaptiveDependency)
+	this.So(err.Error(), should.ContainSubstring, "singleton '*test.CallCounter' captures transient 'test.Driver")
+	this.So(err.Error(), should.NotContainSubstring, "''")
+}
+
+func (this *InjectorFixture) TestCaptiveDependency_DowngradedToWarning() {
+	di := New()
+	err := RegisterScope[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, NewRegularCar, WithCaptivePolicy(CaptiveWarning))
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+
+	this.So(err, should.BeNil)
+	this.So(di.Warnings(), should.HaveLength, 1)
+	this.So(di.Warnings()[0], should.Wrap, ErrorCaptiveDependency)
+
+	err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper, WithCaptivePolicy(CaptiveWarning))
+	this.So(err, should.Wrap, ErrorInvalidOption)
+}
+
 func (this *InjectorFixture) TestNoReturnValues() {
 	di := New()
 	err := RegisterSingleton[Car](di, func() {})
@@ -147,214 +338,831 @@ func (this *InjectorFixture) TestRegisterSingletonError_RuntimeErrorPropagates()
 	this.So(getErr.Error(), should.ContainSubstring, "boom")
 }
 
-func (this *InjectorFixture) TestTransient() {
+func (this *InjectorFixture) TestRegisterInstance() {
+	driver := NewRegularDriver()
 	di := New()
-	err := RegisterTransient[Counter](di, NewCallCounter)
+	err := RegisterInstance[Driver](di, driver)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, NewRegularCar)
 	this.So(err, should.BeNil)
 
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	this.So(skipError(Get[Counter](di)).GetCount(), should.Equal, 0)
+	this.So(skipError(Get[Driver](di)), should.Equal, driver)
+	this.So(skipError(Get[Car](di)).GetDriver(), should.Equal, driver)
+	this.So(skipError(GetByName(di, "Driver")), should.Equal, driver)
 }
 
-func (this *InjectorFixture) TestSingleton() {
+func (this *InjectorFixture) TestRegisterInstance_DecoratedInstanceIsNotClosed() {
+	var closed []string
 	di := New()
-	err := RegisterSingleton[Counter](di, NewCallCounter)
+	err := RegisterInstance[*RecordingCloser](di, NewRecordingCloser("instance", &closed, nil))
+	this.So(err, should.BeNil)
+	err = Decorate[*RecordingCloser](di, func(inner *RecordingCloser) *RecordingCloser { return inner })
 	this.So(err, should.BeNil)
-
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	skipError(Get[Counter](di)).CallMe()
-	this.So(skipError(Get[Counter](di)).GetCount(), should.Equal, 5)
+	_, err = Get[*RecordingCloser](di)
+	this.So(err, should.BeNil)
+
+	this.So(di.Close(), should.BeNil)
+	this.So(closed, should.BeEmpty)
 }
 
-func (this *InjectorFixture) TestScope() {
+func (this *InjectorFixture) TestRegisterInstance_AlreadyRegistered() {
 	di := New()
-	err := RegisterScope[Counter](di, NewCallCounter)
-	this.So(err, should.BeNil)
-	err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
 	this.So(err, should.BeNil)
 
-	err = Verify(di)
-	this.So(err, should.BeNil)
+	err = RegisterInstance[Driver](di, NewRegularDriver())
+	this.So(err, should.Wrap, ErrorAlreadyRegistered)
+}
 
-	cw := skipError(Get[CounterWrapper](di))
-	cw.CallLeft()
-	cw.CallLeft()
-	this.So(cw.GetRightCount(), should.Equal, 2)
+func (this *InjectorFixture) TestRegisterInstance_Nil() {
+	di := New()
+	err := RegisterInstance[Driver](di, nil)
+	this.So(err, should.Wrap, ErrorNotAssignable)
 
-	cw = skipError(Get[CounterWrapper](di))
-	this.So(cw.GetLeftCount(), should.Equal, 0)
-	cw.CallLeft()
-	cw.CallLeft()
-	this.So(cw.GetRightCount(), should.Equal, 2)
+	err = di.RegisterInstance(reflect.TypeFor[Car](), NewRegularDriver())
+	this.So(err, should.Wrap, ErrorNotAssignable)
 }
 
-func (this *InjectorFixture) TestGetCorrectlyFillsInstance() {
+func (this *InjectorFixture) TestNamedRegistrations() {
 	di := New()
-	err := RegisterTransient[Car](di, NewRegularCar)
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
 	this.So(err, should.BeNil)
-	err = RegisterTransient[Driver](di, NewRegularDriver)
+	err = RegisterSingletonNamed[Driver](di, "loop", func() Driver { return NewLoopDriver(nil) })
 	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[Driver](di, "loop", NewRegularDriver)
+	this.So(err, should.Wrap, ErrorAlreadyRegistered)
+	err = RegisterTransient[Car](di, NewRegularCar, WithNamedParameter(0, "loop"))
+	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	car := skipError(Get[Car](di))
-	this.So(car, should.NotBeNil)
-	_, ok := car.(*RegularCar)
-	this.So(ok, should.BeTrue)
-	_, ok = car.GetDriver().(*RegularDriver)
-	this.So(ok, should.BeTrue)
+	this.So(skipError(Get[Driver](di)).GetName(), should.Equal, "Norman")
+	this.So(skipError(GetNamed[Driver](di, "loop")).GetName(), should.Equal, "Lupin")
+	this.So(skipError(Get[Car](di)).GetDriver().GetName(), should.Equal, "Lupin")
+	this.So(skipError(GetByName(di, "Driver[loop]")).(Driver).GetName(), should.Equal, "Lupin")
 }
 
-func (this *InjectorFixture) TestAddSelf() {
+func (this *InjectorFixture) TestNamedParameterNotRegistered() {
 	di := New()
-	err := Verify(di)
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, NewRegularCar, WithNamedParameter(0, "replica"))
 	this.So(err, should.BeNil)
 
-	inj2 := skipError(Get[*Injector](di))
-	this.So(inj2, should.Equal, di)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "Driver[replica]")
+
+	_, err = GetNamed[Driver](di, "replica")
+	this.So(err, should.Wrap, ErrorBadState)
 }
 
-func (this *InjectorFixture) TestGetByNameNotRegistered() {
+func (this *InjectorFixture) TestNamedParameterOutOfRange() {
 	di := New()
-	err := RegisterTransient[Driver](di, NewRegularDriver)
+	err := RegisterTransient[Car](di, NewRegularCar, WithNamedParameter(1, "replica"))
+	this.So(err, should.Wrap, ErrorInvalidOption)
+}
+
+func (this *InjectorFixture) TestGroupMembers() {
+	di := New()
+	err := RegisterGroupMember[Driver](di, SingletonLifecycle, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, NewLoopDriver)
 	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, func() Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	var byName any
-	byName, err = GetByName(di, "Car")
-	this.So(err, should.NotBeNil)
-	this.So(err, should.Wrap, ErrorNotRegistered)
-	this.So(byName, should.BeNil)
+	drivers, err := Call1[[]Driver](di, func(drivers []Driver) []Driver { return drivers })
+	this.So(err, should.BeNil)
+	this.So(drivers, should.HaveLength, 2)
+	this.So(drivers[0].GetName(), should.Equal, "Norman")
+	this.So(drivers[1].GetName(), should.Equal, "Lupin")
+
+	again, _ := Call1[[]Driver](di, func(drivers []Driver) []Driver { return drivers })
+	this.So(again[0], should.PointTo, drivers[0])
+	this.So(again[1], should.NotPointTo, drivers[1])
 }
 
-func (this *InjectorFixture) TestGetByName() {
+func (this *InjectorFixture) TestGroupMembers_VerifyWalksMemberDependencies() {
 	di := New()
-	err := RegisterTransient[Car](di, NewRegularCar)
+	err := RegisterGroupMember[Driver](di, TransientLifecycle, NewRegularDriver)
 	this.So(err, should.BeNil)
-	err = RegisterTransient[Driver](di, NewRegularDriver)
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, NewLoopDriver)
 	this.So(err, should.BeNil)
-	err = Verify(di)
+	err = RegisterTransient[*StringProvider](di, func(drivers []Driver) *StringProvider { return NewStringProvider() })
 	this.So(err, should.BeNil)
 
-	var byName any
-	byName, err = GetByName(di, "Car")
-	this.So(err, should.BeNil)
-	this.So(byName, should.NotBeNil)
-	car, ok := byName.(*RegularCar)
-	this.So(ok, should.BeTrue)
-	_, ok = car.GetDriver().(*RegularDriver)
-	this.So(ok, should.BeTrue)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "Car")
 }
 
-func (this *InjectorFixture) TestGetByNameFromAnotherPackage() {
+func (this *InjectorFixture) TestGroupMembers_KeptApartFromNamedRegistrations() {
 	di := New()
-	err := RegisterTransient[*contracts.ScopedInstance](di, func() *contracts.ScopedInstance { return new(contracts.ScopedInstance) })
+	err := RegisterSingletonNamed[Driver](di, "group#1", NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, NewRegularDriver)
 	this.So(err, should.BeNil)
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, func() Driver { return NewLoopDriver(nil) })
+	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	var byName any
-	byName, err = GetByName(di, "ScopedInstance")
+	drivers, err := Call1[[]Driver](di, func(drivers []Driver) []Driver { return drivers })
 	this.So(err, should.BeNil)
-	this.So(byName, should.NotBeNil)
-	_, ok := byName.(*contracts.ScopedInstance)
-	this.So(ok, should.BeTrue)
-}
+	this.So(drivers, should.HaveLength, 2)
 
-func (this *InjectorFixture) TestCall_Method() {
-	strings := []string{"hello", "world", "how", "are", "you"}
+	_, err = GetByName(di, "Driver[group#0]")
+	this.So(err, should.Wrap, ErrorNotRegistered)
+}
 
+func (this *InjectorFixture) TestMapEntries() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterMapEntry[Driver](di, "regular", SingletonLifecycle, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterMapEntry[Driver](di, "loop", TransientLifecycle, func() Driver { return NewLoopDriver(nil) })
 	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	di.Call(func(sp *StringProvider) {})
-}
+	drivers, err := Call1[map[string]Driver](di, func(drivers map[string]Driver) map[string]Driver { return drivers })
+	this.So(err, should.BeNil)
+	this.So(drivers, should.HaveLength, 2)
+	this.So(drivers["regular"].GetName(), should.Equal, "Norman")
+	this.So(drivers["loop"].GetName(), should.Equal, "Lupin")
 
-func (this *InjectorFixture) TestCall_Method_Variadic() {
-	strings := []string{"hello", "world", "how", "are", "you"}
+	byName, err := GetByName(di, "map[string]Driver[loop]")
+	this.So(err, should.BeNil)
+	this.So(byName.(Driver).GetName(), should.Equal, "Lupin")
+}
 
+func (this *InjectorFixture) TestMapEntries_KeptApartFromNamedRegistrations() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterSingletonNamed[Driver](di, "map#1", NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterMapEntry[Driver](di, "regular", TransientLifecycle, NewRegularDriver)
 	this.So(err, should.BeNil)
+	err = RegisterMapEntry[Driver](di, "loop", TransientLifecycle, func() Driver { return NewLoopDriver(nil) })
+	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	err = di.Call(func(sp ...*StringProvider) {})
-	this.So(err, should.NotBeNil)
-}
+	drivers, err := Call1[map[string]Driver](di, func(drivers map[string]Driver) map[string]Driver { return drivers })
+	this.So(err, should.BeNil)
+	this.So(drivers, should.HaveLength, 2)
 
-func (this *InjectorFixture) TestCall_Method_WrongNumberOfReturns() {
-	strings := []string{"hello", "world", "how", "are", "you"}
+	_, err = GetByName(di, "Driver[map#0]")
+	this.So(err, should.Wrap, ErrorNotRegistered)
+}
 
+func (this *InjectorFixture) TestMapEntries_DuplicateKey() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterMapEntry[Driver](di, "regular", SingletonLifecycle, NewRegularDriver)
 	this.So(err, should.BeNil)
-	err = Verify(di)
+	err = RegisterMapEntry[Driver](di, "regular", SingletonLifecycle, NewRegularDriver)
 	this.So(err, should.BeNil)
 
-	err = di.Call(func(sp *StringProvider) any { return sp.Values[0] })
-	this.So(err, should.NotBeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorDuplicateMapKey)
+	this.So(err.Error(), should.ContainSubstring, "regular")
 }
 
-func (this *InjectorFixture) TestCall_Method_NotAFunction() {
-	strings := []string{"hello", "world", "how", "are", "you"}
-
+func (this *InjectorFixture) TestDecorate() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Decorate[Driver](di, func(inner Driver) Driver { return NewPrefixDriver(inner, "Mr. ") })
+	this.So(err, should.BeNil)
+	err = Decorate[Driver](di, func(inner Driver, sp *StringProvider) Driver { return NewPrefixDriver(inner, sp.Values[0]) })
+	this.So(err, should.BeNil)
+	err = RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider("Dear ") })
 	this.So(err, should.BeNil)
+
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	err = di.Call(strings)
-	this.So(err, should.NotBeNil)
+	driver := skipError(Get[Driver](di))
+	this.So(driver.GetName(), should.Equal, "Dear Mr. Norman")
+	this.So(skipError(Get[Driver](di)), should.Equal, driver)
 }
 
-func (this *InjectorFixture) TestCall1_Method() {
-	strings := []string{"hello", "world", "how", "are", "you"}
+func (this *InjectorFixture) TestDecorate_Errors() {
+	di := New()
+	err := Decorate[Driver](di, func(inner Driver) Driver { return inner })
+	this.So(err, should.Wrap, ErrorNotRegistered)
+
+	err = RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Decorate[Driver](di, func(inner Car) Driver { return nil })
+	this.So(err, should.Wrap, ErrorNotAssignable)
+	err = Decorate[Driver](di, "not a function")
+	this.So(err, should.Wrap, ErrorNotAFunction)
+}
 
+func (this *InjectorFixture) TestDecorate_GroupIsRejected() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterGroupMember[Driver](di, TransientLifecycle, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Decorate[[]Driver](di, func(inner []Driver, counter Counter) []Driver { return inner })
+	this.So(err, should.Wrap, ErrorNotStructOrInterface)
+	err = RegisterGroupMember[Driver](di, TransientLifecycle, func() Driver { return NewLoopDriver(nil) })
 	this.So(err, should.BeNil)
 	err = Verify(di)
 	this.So(err, should.BeNil)
 
-	raw1, e := di.Call1(func(sp *StringProvider) any { return sp.Values[0] })
-	string1 := raw1.(string)
-
-	this.So(string1, should.Equal, strings[0])
-	this.So(e, should.BeNil)
+	drivers, err := Get[[]Driver](di)
+	this.So(err, should.BeNil)
+	this.So(drivers, should.HaveLength, 2)
 }
 
-func (this *InjectorFixture) TestCall2_Method() {
-	strings := []string{"hello", "world", "how", "are", "you"}
-
+func (this *InjectorFixture) TestDecorate_DependencyLoop() {
 	di := New()
-	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
 	this.So(err, should.BeNil)
-	err = Verify(di)
+	err = RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = Decorate[Driver](di, func(inner Driver, car Car) Driver { return inner })
 	this.So(err, should.BeNil)
 
-	raw1, raw2, e := di.Call2(func(sp *StringProvider) (any, any) { return sp.Values[0], sp.Values[1] })
-	string1 := raw1.(string)
-	string2 := raw2.(string)
-
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorDependencyLoop)
+}
+
+func (this *InjectorFixture) TestTransient() {
+	di := New()
+	err := RegisterTransient[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	this.So(skipError(Get[Counter](di)).GetCount(), should.Equal, 0)
+}
+
+func (this *InjectorFixture) TestSingleton() {
+	di := New()
+	err := RegisterSingleton[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	skipError(Get[Counter](di)).CallMe()
+	this.So(skipError(Get[Counter](di)).GetCount(), should.Equal, 5)
+}
+
+func (this *InjectorFixture) TestSingleton_ConstructedOnceUnderConcurrentGets() {
+	var constructions atomic.Int32
+	di := New()
+	err := RegisterSingleton[Counter](di, func() *CallCounter {
+		constructions.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return NewCallCounter()
+	})
+	this.So(err, should.BeNil)
+	err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	var waiter sync.WaitGroup
+	counters := make([]Counter, 32)
+	for iGoroutine := range counters {
+		waiter.Go(func() {
+			if iGoroutine%2 == 0 {
+				counters[iGoroutine], _ = Get[Counter](di)
+			} else {
+				_ = di.Call(func(wrapper CounterWrapper) { counters[iGoroutine], _ = Get[Counter](di) })
+			}
+		})
+	}
+	waiter.Wait()
+
+	this.So(constructions.Load(), should.Equal, 1)
+	for _, counter := range counters {
+		this.So(counter, should.Equal, counters[0])
+	}
+}
+
+func (this *InjectorFixture) TestSingleton_ConstructorErrorIsNotCached() {
+	attempts := 0
+	di := New()
+	err := RegisterSingletonError[Driver](di, func() (Driver, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, errors.New("boom")
+		}
+
+		return NewRegularDriver(), nil
+	})
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	_, err = Get[Driver](di)
+	this.So(err, should.NotBeNil)
+
+	driver, err := Get[Driver](di)
+	this.So(err, should.BeNil)
+	this.So(driver, should.NotBeNil)
+	this.So(skipError(Get[Driver](di)), should.Equal, driver)
+	this.So(attempts, should.Equal, 2)
+}
+
+func (this *InjectorFixture) TestVerify_ConstructsEagerSingletons() {
+	drivers, cars := 0, 0
+	di := New()
+	err := RegisterSingleton[Driver](di, func() Driver { drivers++; return NewRegularDriver() }, Eager())
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, func(driver Driver) Car { cars++; return NewRegularCar(driver) })
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+
+	this.So(err, should.BeNil)
+	this.So(drivers, should.Equal, 1)
+	this.So(cars, should.Equal, 0)
+	this.So(skipError(Get[Car](di)).GetDriver(), should.Equal, skipError(Get[Driver](di)))
+	this.So(drivers, should.Equal, 1)
+}
+
+func (this *InjectorFixture) TestVerifyAndBuild_ReportsEveryConstructorErrorWithPath() {
+	cars := 0
+	di := New()
+	err := RegisterTransientError[Driver](di, func() (Driver, error) { return nil, errors.New("bad dsn") })
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, func(driver Driver) Car { cars++; return NewRegularCar(driver) })
+	this.So(err, should.BeNil)
+	err = RegisterSingletonError[Counter](di, func() (Counter, error) { return nil, errors.New("missing file") })
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[CounterWrapper](di, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+
+	err = VerifyAndBuild(di)
+
+	this.So(err, should.NotBeNil)
+	this.So(err.Error(), should.ContainSubstring, "bad dsn\n\ttest.Car -> test.Driver")
+	this.So(err.Error(), should.ContainSubstring, "missing file\n\ttest.Counter")
+	this.So(err.Error(), should.NotContainSubstring, "CounterWrapper")
+	this.So(cars, should.Equal, 0)
+	_, err = Get[Car](di)
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestEager_RequiresSingleton() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver, Eager())
+	this.So(err, should.Wrap, ErrorInvalidOption)
+}
+
+func (this *InjectorFixture) TestScope() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	cw := skipError(Get[CounterWrapper](di))
+	cw.CallLeft()
+	cw.CallLeft()
+	this.So(cw.GetRightCount(), should.Equal, 2)
+
+	cw = skipError(Get[CounterWrapper](di))
+	this.So(cw.GetLeftCount(), should.Equal, 0)
+	cw.CallLeft()
+	cw.CallLeft()
+	this.So(cw.GetRightCount(), should.Equal, 2)
+}
+
+func (this *InjectorFixture) TestNewScope_SharesScopedInstances() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	scope := di.NewScope()
+	skipError(GetScoped[Counter](scope)).CallMe()
+	err = scope.Call(func(counter Counter) { counter.CallMe() })
+	this.So(err, should.BeNil)
+	this.So(skipError(GetScoped[Counter](scope)).GetCount(), should.Equal, 2)
+
+	this.So(skipError(di.NewScope().Get(reflect.TypeFor[Counter]())).(Counter).GetCount(), should.Equal, 0)
+}
+
+func (this *InjectorFixture) TestNewScope_CloseDisposesInReverseOrder() {
+	var closed []string
+	boom := errors.New("boom")
+	di := New()
+	err := RegisterScopeNamed[*RecordingCloser](di, "first", func() *RecordingCloser { return NewRecordingCloser("first", &closed, nil) })
+	this.So(err, should.BeNil)
+	err = RegisterScopeNamed[*RecordingCloser](di, "second", func() *RecordingCloser { return NewRecordingCloser("second", &closed, boom) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	scope := di.NewScope()
+	_, err = scope.GetNamed(reflect.TypeFor[*RecordingCloser](), "first")
+	this.So(err, should.BeNil)
+	_, err = scope.GetNamed(reflect.TypeFor[*RecordingCloser](), "second")
+	this.So(err, should.BeNil)
+
+	err = scope.Close()
+	this.So(err, should.Wrap, boom)
+	this.So(closed, should.Equal, []string{"second", "first"})
+
+	this.So(scope.Close(), should.BeNil)
+	_, err = scope.GetNamed(reflect.TypeFor[*RecordingCloser](), "first")
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestNewScope_CloseWaitsForResolutionsInProgress() {
+	var closed []string
+	constructing := make(chan struct{})
+	release := make(chan struct{})
+	di := New()
+	err := RegisterScope[*RecordingCloser](di, func() *RecordingCloser {
+		close(constructing)
+		<-release
+		return NewRecordingCloser("late", &closed, nil)
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	scope := di.NewScope()
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := GetScoped[*RecordingCloser](scope)
+		resolved <- err
+	}()
+	<-constructing
+
+	scopeClosed := make(chan error, 1)
+	go func() { scopeClosed <- scope.Close() }()
+	select {
+	case <-scopeClosed:
+		this.Error("scope was closed while a resolution was in progress")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	this.So(<-resolved, should.BeNil)
+	this.So(<-scopeClosed, should.BeNil)
+	this.So(closed, should.Equal, []string{"late"})
+}
+
+func (this *InjectorFixture) TestNewScope_LazyAndProviderResolveThroughTheScope() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[*StringProvider](di, func(counters Provider[Counter]) *StringProvider {
+		skipError(counters.Get()).CallMe()
+		return NewStringProvider()
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	scope := di.NewScope()
+	var provider Provider[Counter]
+	err = scope.Call(func(counter Counter, lazy Lazy[Counter], counters Provider[Counter], _ *StringProvider) {
+		this.So(skipError(lazy.Get()), should.PointTo, counter)
+		this.So(skipError(counters.Get()), should.PointTo, counter)
+		provider = counters
+	})
+	this.So(err, should.BeNil)
+	this.So(skipError(GetScoped[Counter](scope)).GetCount(), should.Equal, 0)
+
+	this.So(scope.Close(), should.BeNil)
+	_, err = provider.Get()
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestClose_ConcurrentWithGet() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	waiter := sync.WaitGroup{}
+	for range 8 {
+		waiter.Add(1)
+		go func() {
+			defer waiter.Done()
+			for range 100 {
+				_, _ = Get[Driver](di)
+			}
+		}()
+	}
+
+	this.So(di.Close(), should.BeNil)
+	waiter.Wait()
+	_, err = Get[Driver](di)
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestClose_DisposesSingletonsInReverseDependencyOrder() {
+	var disposed []string
+	boom := errors.New("boom")
+	di := New()
+	err := RegisterSingletonNamed[*RecordingCloser](di, "inner", func() *RecordingCloser { return NewRecordingCloser("inner", &disposed, boom) })
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[*RecordingCloser](di, "unused", func() *RecordingCloser { return NewRecordingCloser("unused", &disposed, nil) })
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[*RecordingService](di, func(inner *RecordingCloser) *RecordingService {
+		return NewRecordingService("outer", &disposed, nil)
+	}, WithNamedParameter(0, "inner"))
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	_, err = Get[*RecordingService](di)
+	this.So(err, should.BeNil)
+
+	err = di.Close()
+	this.So(err, should.Wrap, boom)
+	this.So(disposed, should.Equal, []string{"stop outer", "inner"})
+
+	_, err = Get[*RecordingService](di)
+	this.So(err, should.Wrap, ErrorBadState)
+	this.So(di.Close(), should.BeNil)
+}
+
+func (this *InjectorFixture) TestShutdown_CanceledContext() {
+	var disposed []string
+	di := New()
+	err := RegisterSingleton[*RecordingCloser](di, func() *RecordingCloser { return NewRecordingCloser("closer", &disposed, nil) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+	_, err = Get[*RecordingCloser](di)
+	this.So(err, should.BeNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = di.Shutdown(ctx)
+	this.So(err, should.Wrap, context.Canceled)
+	this.So(disposed, should.BeEmpty)
+	_, err = Get[*RecordingCloser](di)
+	this.So(err, should.Wrap, ErrorBadState)
+
+	this.So(di.Close(), should.BeNil)
+	this.So(disposed, should.Equal, []string{"closer"})
+	this.So(di.Close(), should.BeNil)
+	this.So(disposed, should.Equal, []string{"closer"})
+}
+
+func (this *InjectorFixture) TestStartAndStop_RunInDependencyOrder() {
+	var events []string
+	di := New()
+	err := RegisterSingleton[*RecordingService](di, func(driver Driver) *RecordingService {
+		return NewRecordingService("service", &events, nil)
+	})
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Driver](di, NewRegularDriver,
+		OnStart(func(ctx context.Context, driver Driver) error {
+			events = append(events, "start "+driver.GetName())
+			return nil
+		}),
+		OnStop(func(ctx context.Context, driver Driver) error {
+			events = append(events, "stop "+driver.GetName())
+			return nil
+		}))
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Start(context.Background())
+	this.So(err, should.BeNil)
+	this.So(di.Start(context.Background()), should.Wrap, ErrorBadState)
+
+	err = di.Close()
+	this.So(err, should.BeNil)
+	this.So(events, should.Equal, []string{"start Norman", "start service", "stop service", "stop Norman"})
+}
+
+func (this *InjectorFixture) TestStart_RollsBackOnFailure() {
+	var events []string
+	boom := errors.New("boom")
+	di := New()
+	err := RegisterSingletonNamed[*RecordingService](di, "first", func() *RecordingService {
+		return NewRecordingService("first", &events, nil)
+	})
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[*RecordingService](di, "second", func(first *RecordingService) *RecordingService {
+		return NewRecordingService("second", &events, boom)
+	}, WithNamedParameter(0, "first"))
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Start(context.Background())
+	this.So(err, should.Wrap, boom)
+	this.So(events, should.Equal, []string{"start first", "start second", "stop first"})
+}
+
+func (this *InjectorFixture) TestStart_HookTimeout() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver,
+		OnStart(func(ctx context.Context, driver Driver) error {
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}),
+		WithHookTimeout(time.Millisecond))
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Start(context.Background())
+	this.So(err, should.Wrap, context.DeadlineExceeded)
+}
+
+func (this *InjectorFixture) TestHooks_RequireSingleton() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver, OnStart(func(ctx context.Context, driver Driver) error { return nil }))
+	this.So(err, should.Wrap, ErrorInvalidOption)
+	err = RegisterSingleton[Driver](di, NewRegularDriver, OnStart(func(ctx context.Context, car Car) error { return nil }))
+	this.So(err, should.Wrap, ErrorInvalidOption)
+}
+
+func (this *InjectorFixture) TestGetCorrectlyFillsInstance() {
+	di := New()
+	err := RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	car := skipError(Get[Car](di))
+	this.So(car, should.NotBeNil)
+	_, ok := car.(*RegularCar)
+	this.So(ok, should.BeTrue)
+	_, ok = car.GetDriver().(*RegularDriver)
+	this.So(ok, should.BeTrue)
+}
+
+func (this *InjectorFixture) TestAddSelf() {
+	di := New()
+	err := Verify(di)
+	this.So(err, should.BeNil)
+
+	inj2 := skipError(Get[*Injector](di))
+	this.So(inj2, should.Equal, di)
+}
+
+func (this *InjectorFixture) TestGetByNameNotRegistered() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	var byName any
+	byName, err = GetByName(di, "Car")
+	this.So(err, should.NotBeNil)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(byName, should.BeNil)
+}
+
+func (this *InjectorFixture) TestGetByName() {
+	di := New()
+	err := RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	var byName any
+	byName, err = GetByName(di, "Car")
+	this.So(err, should.BeNil)
+	this.So(byName, should.NotBeNil)
+	car, ok := byName.(*RegularCar)
+	this.So(ok, should.BeTrue)
+	_, ok = car.GetDriver().(*RegularDriver)
+	this.So(ok, should.BeTrue)
+}
+
+func (this *InjectorFixture) TestGetByNameFromAnotherPackage() {
+	di := New()
+	err := RegisterTransient[*contracts.ScopedInstance](di, func() *contracts.ScopedInstance { return new(contracts.ScopedInstance) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	var byName any
+	byName, err = GetByName(di, "ScopedInstance")
+	this.So(err, should.BeNil)
+	this.So(byName, should.NotBeNil)
+	_, ok := byName.(*contracts.ScopedInstance)
+	this.So(ok, should.BeTrue)
+}
+
+func (this *InjectorFixture) TestCall_Method() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	di.Call(func(sp *StringProvider) {})
+}
+
+func (this *InjectorFixture) TestCall_Method_Variadic() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Call(func(sp ...*StringProvider) {})
+	this.So(err, should.NotBeNil)
+}
+
+func (this *InjectorFixture) TestCall_Method_WrongNumberOfReturns() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Call(func(sp *StringProvider) any { return sp.Values[0] })
+	this.So(err, should.NotBeNil)
+}
+
+func (this *InjectorFixture) TestCall_Method_NotAFunction() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	err = di.Call(strings)
+	this.So(err, should.NotBeNil)
+}
+
+func (this *InjectorFixture) TestCall1_Method() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	raw1, e := di.Call1(func(sp *StringProvider) any { return sp.Values[0] })
+	string1 := raw1.(string)
+
+	this.So(string1, should.Equal, strings[0])
+	this.So(e, should.BeNil)
+}
+
+func (this *InjectorFixture) TestCall2_Method() {
+	strings := []string{"hello", "world", "how", "are", "you"}
+
+	di := New()
+	err := RegisterTransient[*StringProvider](di, func() *StringProvider { return NewStringProvider(strings...) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.BeNil)
+
+	raw1, raw2, e := di.Call2(func(sp *StringProvider) (any, any) { return sp.Values[0], sp.Values[1] })
+	string1 := raw1.(string)
+	string2 := raw2.(string)
+
 	this.So(string1, should.Equal, strings[0])
 	this.So(string2, should.Equal, strings[1])
 	this.So(e, should.BeNil)
@@ -404,6 +1212,33 @@ func (this *InjectorFixture) TestCall4_Method() {
 	this.So(e, should.BeNil)
 }
 
+func (this *InjectorFixture) TestCall_DoesNotRequireVerify() {
+	di := New()
+	err := RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+
+	name, err := Call1[string](di, func(driver Driver) string { return driver.GetName() })
+	this.So(err, should.BeNil)
+	this.So(name, should.Equal, "Norman")
+
+	this.So(di.Close(), should.BeNil)
+	_, err = Call1[string](di, func(driver Driver) string { return driver.GetName() })
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestCallX_ReturnErrorsWithoutPanicking() {
+	di := New()
+	this.So(Verify(di), should.BeNil)
+	_, err := di.Call1(func() {})
+	this.So(err, should.Wrap, ErrorWrongNumberOfReturns)
+	_, _, err = di.Call2("not a function")
+	this.So(err, should.Wrap, ErrorNotAFunction)
+	_, _, _, err = Call3[int, int, int](di, func() int { return 1 })
+	this.So(err, should.Wrap, ErrorWrongNumberOfReturns)
+	_, _, _, _, err = Call4[int, int, int, int](di, func() int { return 1 })
+	this.So(err, should.Wrap, ErrorWrongNumberOfReturns)
+}
+
 func (this *InjectorFixture) TestCallN_Method() {
 	strings := []string{"hello", "world", "how", "are", "you"}
 
@@ -575,6 +1410,671 @@ func (this *InjectorFixture) TestCallN_Function() {
 	this.So(e, should.BeNil)
 }
 
+func (this *InjectorFixture) TestWriteGraph_DOT() {
+	di := New()
+	err := RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+	_, err = Get[Car](di)
+	this.So(err, should.BeNil)
+
+	output := &bytes.Buffer{}
+	err = di.WriteGraph(output, GraphDOT)
+
+	this.So(err, should.BeNil)
+	this.So(output.String(), should.Equal, strings.Join([]string{
+		"digraph injector {",
+		`	"*injector.Injector" [label="*injector.Injector\nsingleton", style=filled];`,
+		`	"test.Car" [label="test.Car\nsingleton", style=filled];`,
+		`	"test.Driver" [label="test.Driver\ntransient"];`,
+		`	"test.Car" -> "test.Driver" [label="0"];`,
+		"}",
+		"",
+	}, "\n"))
+}
+
+func (this *InjectorFixture) TestWriteGraph_MermaidAndJSON() {
+	di := New()
+	err := RegisterTransient[CounterWrapper](di, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+
+	mermaid := &bytes.Buffer{}
+	err = di.WriteGraph(mermaid, GraphMermaid)
+	this.So(err, should.BeNil)
+	this.So(mermaid.String(), should.StartWith, "flowchart LR\n")
+	this.So(mermaid.String(), should.ContainSubstring, "test_2e_Counter[\"test.Counter<br/>unregistered\"]\n\tclass test_2e_Counter unregistered\n")
+	this.So(mermaid.String(), should.ContainSubstring, "test_2e_CounterWrapper -->|1| test_2e_Counter\n")
+
+	document := &bytes.Buffer{}
+	err = di.WriteGraph(document, GraphJSON)
+	this.So(err, should.BeNil)
+	var graph struct {
+		Nodes []struct {
+			Key        string `json:"key"`
+			Lifecycle  string `json:"lifecycle"`
+			Registered bool   `json:"registered"`
+		} `json:"nodes"`
+		Edges []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"edges"`
+	}
+	this.So(json.Unmarshal(document.Bytes(), &graph), should.BeNil)
+	this.So(graph.Nodes, should.HaveLength, 3)
+	this.So(graph.Nodes[1].Lifecycle, should.Equal, "transient")
+	this.So(graph.Nodes[2].Registered, should.BeFalse)
+	this.So(graph.Edges, should.HaveLength, 2)
+	this.So(graph.Edges[0].From, should.Equal, "test.CounterWrapper")
+
+	this.So(di.WriteGraph(document, GraphFormat(42)), should.Wrap, ErrorInvalidOption)
+}
+
+func (this *InjectorFixture) TestRegistrations() {
+	di := New()
+	err := RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterTransientNamed[Driver](di, "loop", NewLoopDriver)
+	this.So(err, should.BeNil)
+	err = RegisterMapEntryError[Counter](di, "calls", ScopeLifecycle, func() (Counter, error) { return NewCallCounter(), nil })
+	this.So(err, should.BeNil)
+
+	registrations := make(map[string]Registration)
+	for registration := range di.Registrations() {
+		registrations[registration.Key.String()] = registration
+	}
+
+	this.So(registrations, should.HaveLength, 5)
+	car := registrations["test.Car"]
+	this.So(car.Lifecycle, should.Equal, SingletonLifecycle)
+	this.So(car.Constructor, should.Equal, reflect.TypeOf(NewRegularCar))
+	this.So(car.ReturnsError, should.BeFalse)
+	this.So(car.Dependencies, should.Equal, []Key{{Type: reflect.TypeFor[Driver]()}})
+	this.So(car.Name, should.Equal, "Car")
+	this.So(registrations["test.Driver[loop]"].Name, should.Equal, "Driver[loop]")
+	this.So(registrations["test.Counter{map#0}"].Name, should.Equal, "map[string]Counter[calls]")
+	this.So(registrations["test.Counter{map#0}"].ReturnsError, should.BeTrue)
+	this.So(registrations["map[string]test.Counter"].Name, should.BeEmpty)
+	this.So(registrations["*injector.Injector"].Instantiated, should.BeTrue)
+	this.So(car.Instantiated, should.BeFalse)
+}
+
+func (this *InjectorFixture) TestNewChild_FallsBackToParentAndShadowsIt() {
+	parent := New()
+	err := RegisterSingleton[Driver](parent, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](parent, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(parent), should.BeNil)
+
+	child := parent.NewChild()
+	err = RegisterSingleton[Driver](child, func() Driver { return NewLoopDriver(nil) })
+	this.So(err, should.BeNil)
+	err = RegisterTransient[CounterWrapper](child, NewCallCounterWrapper)
+	this.So(err, should.BeNil)
+	this.So(Verify(child), should.Wrap, ErrorNotRegistered)
+	err = RegisterScope[Counter](child, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(child), should.BeNil)
+
+	this.So(skipError(Get[Driver](child)).GetName(), should.Equal, "Lupin")
+	this.So(skipError(Get[Driver](parent)).GetName(), should.Equal, "Norman")
+	this.So(skipError(Get[Car](child)).GetDriver(), should.Equal, skipError(Get[Driver](parent)))
+	this.So(skipError(GetByName(child, "Car")), should.NotBeNil)
+	this.So(skipError(Get[*Injector](child)), should.Equal, child)
+	_, err = Get[Counter](parent)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+}
+
+func (this *InjectorFixture) TestNewChild_FailsOnceItsParentIsClosed() {
+	root := New()
+	err := RegisterSingleton[Driver](root, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(root), should.BeNil)
+	child := root.NewChild()
+	err = RegisterTransient[Car](child, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(child), should.BeNil)
+	this.So(skipError(Get[Car](child)), should.NotBeNil)
+
+	this.So(root.Close(), should.BeNil)
+	_, err = Get[Car](child)
+	this.So(err, should.Wrap, ErrorBadState)
+	this.So(err.Error(), should.ContainSubstring, "test.Car -> test.Driver")
+}
+
+func (this *InjectorFixture) TestNewChild_FailsWhileItsParentIsNotVerified() {
+	root := New()
+	err := RegisterSingleton[Driver](root, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](root, NewRegularCar, WithNamedParameter(0, "missing"))
+	this.So(err, should.BeNil)
+	child := root.NewChild()
+	err = RegisterTransient[CounterWrapper](child, func(driver Driver) CounterWrapper { return NewCallCounterWrapper(NewCallCounter(), NewCallCounter()) })
+	this.So(err, should.BeNil)
+
+	this.So(Verify(child), should.Wrap, ErrorBadState)
+	this.So(Verify(root), should.Wrap, ErrorNotRegistered)
+	err = Verify(child)
+	this.So(err, should.Wrap, ErrorBadState)
+	this.So(err.Error(), should.ContainSubstring, "verification error")
+	_, err = Get[CounterWrapper](child)
+	this.So(err, should.Wrap, ErrorBadState)
+	_, err = Get[Driver](child)
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestNewChild_OwnsItsSingletons() {
+	var closed []string
+	parent := New()
+	err := RegisterSingleton[*RecordingCloser](parent, func() *RecordingCloser { return NewRecordingCloser("parent", &closed, nil) })
+	this.So(err, should.BeNil)
+	this.So(Verify(parent), should.BeNil)
+
+	child := parent.NewChild()
+	err = RegisterSingletonNamed[*RecordingCloser](child, "child", func(*RecordingCloser) *RecordingCloser {
+		return NewRecordingCloser("child", &closed, nil)
+	})
+	this.So(err, should.BeNil)
+	this.So(VerifyAndBuild(child), should.BeNil)
+
+	this.So(child.Close(), should.BeNil)
+
+	this.So(closed, should.Equal, []string{"child"})
+	_, err = Get[*RecordingCloser](parent)
+	this.So(err, should.BeNil)
+	this.So(parent.Close(), should.BeNil)
+	this.So(closed, should.Equal, []string{"child", "parent"})
+}
+
+func (this *InjectorFixture) TestReplace_DropsDependentSingletons() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+	original := skipError(Get[Car](di))
+
+	fake := NewLoopDriver(nil)
+	restore, err := ReplaceInstance[Driver](di, fake)
+	this.So(err, should.BeNil)
+	_, err = Get[Car](di)
+	this.So(err, should.Wrap, ErrorBadState)
+	this.So(Verify(di), should.BeNil)
+	replaced := skipError(Get[Car](di))
+	this.So(replaced.GetDriver(), should.Equal, fake)
+	this.So(replaced, should.NotPointTo, original)
+
+	restore()
+	this.So(Verify(di), should.BeNil)
+	this.So(skipError(Get[Car](di)).GetDriver(), should.Equal, original.GetDriver())
+	this.So(skipError(GetByName(di, "Driver")), should.Equal, original.GetDriver())
+}
+
+func (this *InjectorFixture) TestReplace_Errors() {
+	di := New()
+	_, err := Replace[Driver](di, TransientLifecycle, NewRegularDriver)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+
+	err = RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	_, err = ReplaceError[Driver](di, TransientLifecycle, NewRegularDriver)
+	this.So(err, should.Wrap, ErrorWrongNumberOfReturns)
+	_, err = ReplaceInstance[Driver](di, nil)
+	this.So(err, should.Wrap, ErrorNotAssignable)
+}
+
+func (this *InjectorFixture) TestRegisterStruct() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[Driver](di, "backup", func() Driver { return NewLoopDriver(nil) })
+	this.So(err, should.BeNil)
+	err = RegisterStruct[*TaggedGarage](di, TransientLifecycle)
+	this.So(err, should.BeNil)
+	err = RegisterStruct[TaggedGarage](di, SingletonLifecycle)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	garage := skipError(Get[*TaggedGarage](di))
+	this.So(garage.Driver.GetName(), should.Equal, "Norman")
+	this.So(garage.Backup.GetName(), should.Equal, "Lupin")
+	this.So(garage.Counter, should.BeNil)
+	this.So(skipError(Get[*TaggedGarage](di)), should.NotPointTo, garage)
+	this.So(skipError(Get[TaggedGarage](di)).Backup, should.Equal, garage.Backup)
+
+	err = RegisterTransient[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+	this.So(skipError(Get[*TaggedGarage](di)).Counter, should.NotBeNil)
+}
+
+func (this *InjectorFixture) TestRegisterStruct_VerifiesFieldEdges() {
+	di := New()
+	err := RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Driver](di, func(driver *TaggedDriver) Driver { return driver })
+	this.So(err, should.BeNil)
+	err = RegisterStruct[*TaggedDriver](di, TransientLifecycle)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorDependencyLoop)
+
+	err = RegisterStruct[*TaggedGarage](di, TransientLifecycle)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "Driver[backup]")
+	this.So(err.Error(), should.NotContainSubstring, "'Counter'")
+}
+
+func (this *InjectorFixture) TestRegisterStruct_Errors() {
+	di := New()
+	this.So(RegisterStruct[*BadlyTagged](di, TransientLifecycle), should.Wrap, ErrorInvalidTag)
+	this.So(RegisterStruct[Driver](di, TransientLifecycle), should.Wrap, ErrorNotStructOrInterface)
+}
+
+func (this *InjectorFixture) TestInject_SharesOneScopePerCall() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	first := &CounterFixture{}
+	this.So(di.Inject(first), should.BeNil)
+	this.So(first.Left, should.NotBeNil)
+	this.So(first.Left, should.Equal, first.Right)
+
+	second := &CounterFixture{}
+	this.So(Inject(di, second), should.BeNil)
+	this.So(second.Left, should.NotPointTo, first.Left)
+}
+
+func (this *InjectorFixture) TestInject_NamesEveryUnsatisfiedField() {
+	di := New()
+	this.So(Verify(di), should.BeNil)
+
+	garage := &TaggedGarage{Label: "main"}
+	err := di.Inject(garage)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "field 'test.TaggedGarage.Driver'")
+	this.So(err.Error(), should.ContainSubstring, "field 'test.TaggedGarage.Backup'")
+	this.So(err.Error(), should.NotContainSubstring, "field 'test.TaggedGarage.Counter'")
+	this.So(garage, should.Equal, &TaggedGarage{Label: "main"})
+
+	err = RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterSingletonNamed[Driver](di, "backup", func() Driver { return NewLoopDriver(nil) })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+	this.So(di.Inject(garage), should.BeNil)
+	this.So(garage.Driver.GetName(), should.Equal, "Norman")
+	this.So(garage.Backup.GetName(), should.Equal, "Lupin")
+	this.So(garage.Label, should.Equal, "main")
+
+	this.So(di.Inject(TaggedGarage{}), should.Wrap, ErrorNotStructOrInterface)
+	this.So(di.Inject((*TaggedGarage)(nil)), should.Wrap, ErrorNotStructOrInterface)
+	this.So(di.Inject(&BadlyTagged{}), should.Wrap, ErrorInvalidTag)
+}
+
+func (this *InjectorFixture) TestRegisterFactory_ForwardsRuntimeArguments() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = RegisterFactory[DriverFactory](di, NewPrefixDriver)
+	this.So(err, should.BeNil)
+	err = RegisterFactory[func(prefix string) (Driver, error)](di, func(inner Driver, prefix string) (Driver, error) {
+		if prefix == "" {
+			return nil, errors.New("empty prefix")
+		}
+
+		return NewPrefixDriver(inner, prefix), nil
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	factory := skipError(Get[DriverFactory](di))
+	this.So(factory("Mr. ").GetName(), should.Equal, "Mr. Norman")
+	this.So(factory("Dr. ").GetName(), should.Equal, "Dr. Norman")
+
+	checked := skipError(Get[func(prefix string) (Driver, error)](di))
+	driver, err := checked("Sir ")
+	this.So(err, should.BeNil)
+	this.So(driver.GetName(), should.Equal, "Sir Norman")
+	_, err = checked("")
+	this.So(err, should.Resemble, errors.New("empty prefix"))
+
+	value, err := GetByName(di, "DriverFactory")
+	this.So(err, should.BeNil)
+	this.So(value, should.HaveSameTypeAs, DriverFactory(nil))
+}
+
+func (this *InjectorFixture) TestRegisterFactory_VerifiesInjectedParameters() {
+	di := New()
+	err := RegisterFactory[DriverFactory](di, NewPrefixDriver)
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "test.DriverFactory -> test.Driver")
+
+	err = RegisterTransient[Driver](di, func(factory DriverFactory) Driver { return factory("Loop ") })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorDependencyLoop)
+}
+
+func (this *InjectorFixture) TestRegisterFactory_Errors() {
+	di := New()
+	this.So(RegisterFactory[Driver](di, NewPrefixDriver), should.Wrap, ErrorNotAFunction)
+	this.So(RegisterFactory[DriverFactory](di, "constructor"), should.Wrap, ErrorNotAFunction)
+	this.So(RegisterFactory[DriverFactory](di, NewRegularDriver), should.Wrap, ErrorNotAssignable)
+	this.So(RegisterFactory[DriverFactory](di, NewCallCounter), should.Wrap, ErrorNotAssignable)
+	this.So(RegisterFactory[func(count int) Driver](di, NewPrefixDriver), should.Wrap, ErrorNotAssignable)
+	this.So(RegisterFactory[DriverFactory](di, func(prefix string) (Driver, error) { return nil, nil }), should.Wrap, ErrorWrongNumberOfReturns)
+	this.So(RegisterFactory[func(prefix string) (Driver, bool)](di, NewPrefixDriver), should.Wrap, ErrorWrongNumberOfReturns)
+	this.So(RegisterFactory[func(prefixes ...string) Driver](di, NewPrefixDriver), should.Wrap, ErrorVariadicArguments)
+	this.So(RegisterFactory[DriverFactory](di, NewPrefixDriver), should.BeNil)
+	this.So(RegisterFactory[DriverFactory](di, NewPrefixDriver), should.Wrap, ErrorAlreadyRegistered)
+}
+
+func (this *InjectorFixture) TestLazy_BreaksDependencyLoops() {
+	di := New()
+	var lazyDriver Lazy[Driver]
+	err := RegisterSingleton[Car](di, func(driver Lazy[Driver]) Car {
+		lazyDriver = driver
+		return NewRegularCar(nil)
+	})
+	this.So(err, should.BeNil)
+	err = RegisterSingleton[Driver](di, NewLoopDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	this.So(skipError(Get[Car](di)), should.NotBeNil)
+	driver, err := lazyDriver.Get()
+	this.So(err, should.BeNil)
+	this.So(driver, should.Equal, skipError(Get[Driver](di)))
+}
+
+func (this *InjectorFixture) TestLazyAndProvider_ResolveOnceAndEveryCall() {
+	di := New()
+	constructed := 0
+	err := RegisterTransient[Counter](di, func() Counter {
+		constructed++
+		return NewCallCounter()
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	err = Call(di, func(lazy Lazy[Counter], provider Provider[Counter]) {
+		this.So(constructed, should.Equal, 0)
+		first := skipError(lazy.Get())
+		this.So(skipError(lazy.Get()), should.PointTo, first)
+		this.So(constructed, should.Equal, 1)
+		this.So(skipError(provider.Get()), should.NotPointTo, skipError(provider.Get()))
+		this.So(constructed, should.Equal, 3)
+	})
+	this.So(err, should.BeNil)
+
+	_, err = Lazy[Counter](nil).Get()
+	this.So(err, should.Wrap, ErrorNotRegistered)
+}
+
+func (this *InjectorFixture) TestDecorate_LazyAndOptionalParameters() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = Decorate[Driver](di, func(inner Driver, counter Lazy[Counter], missing Optional[Car]) Driver {
+		return NewPrefixDriver(inner, strconv.FormatBool(missing.Present())+" ")
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorNotRegistered)
+
+	err = RegisterTransient[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+	this.So(skipError(Get[Driver](di)).GetName(), should.Equal, "false Norman")
+}
+
+func (this *InjectorFixture) TestLazyAndProvider_NilValue() {
+	di := New()
+	err := RegisterTransient[Driver](di, func() Driver { return nil })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	err = Call(di, func(lazy Lazy[Driver], provider Provider[Driver]) {
+		driver, err := lazy.Get()
+		this.So(err, should.BeNil)
+		this.So(driver, should.BeNil)
+		driver, err = provider.Get()
+		this.So(err, should.BeNil)
+		this.So(driver, should.BeNil)
+	})
+	this.So(err, should.BeNil)
+}
+
+func (this *InjectorFixture) TestLazy_PointerIsAPlainType() {
+	di := New()
+	err := RegisterSingleton[Car](di, func(driver *Lazy[Driver]) Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorNotRegistered)
+}
+
+func (this *InjectorFixture) TestLazy_VerifiesDeferredDependencies() {
+	di := New()
+	err := RegisterSingleton[Car](di, func(driver Provider[Driver]) Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "test.Car -> test.Driver")
+
+	err = RegisterScope[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+}
+
+func (this *InjectorFixture) TestOptional_AbsentWhenNotRegistered() {
+	di := New()
+	var counter Optional[Counter]
+	err := RegisterTransient[Car](di, func(optional Optional[Counter]) Car {
+		counter = optional
+		return NewRegularCar(nil)
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	_, _ = Get[Car](di)
+	value, present := counter.Get()
+	this.So(present, should.BeFalse)
+	this.So(value, should.BeNil)
+
+	err = RegisterSingleton[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	_, _ = Get[Car](di)
+	value, present = counter.Get()
+	this.So(present, should.BeTrue)
+	this.So(value, should.Equal, skipError(Get[Counter](di)))
+}
+
+func (this *InjectorFixture) TestOptional_VerifiesDependenciesWhenPresent() {
+	di := New()
+	err := RegisterTransient[Car](di, func(optional Optional[Counter]) Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Counter](di, func(driver Driver) Counter { return NewCallCounter() })
+	this.So(err, should.BeNil)
+	err = Verify(di)
+	this.So(err, should.Wrap, ErrorNotRegistered)
+	this.So(err.Error(), should.ContainSubstring, "test.Car -> test.Counter -> test.Driver")
+}
+
+func (this *InjectorFixture) TestOptional_PointerIsAPlainType() {
+	di := New()
+	err := RegisterSingleton[Car](di, func(counter *Optional[Counter]) Car { return NewRegularCar(nil) })
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.Wrap, ErrorNotRegistered)
+}
+
+func (this *InjectorFixture) TestOptional_CallAndInject() {
+	di := New()
+	err := RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	err = Call(di, func(driver Optional[Driver], counter Optional[Counter]) {
+		this.So(driver.Present(), should.BeTrue)
+		this.So(counter.Present(), should.BeFalse)
+	})
+	this.So(err, should.BeNil)
+
+	target := &struct {
+		Driver  Optional[Driver]  `di:"inject"`
+		Counter Optional[Counter] `di:"inject"`
+	}{}
+	this.So(di.Inject(target), should.BeNil)
+	this.So(target.Driver.Present(), should.BeTrue)
+	this.So(target.Counter.Present(), should.BeFalse)
+}
+
+func (this *InjectorFixture) TestGetContext_PassesContextToConstructors() {
+	type contextKey struct{}
+	ctx := context.WithValue(context.Background(), contextKey{}, "request")
+	di := New()
+	var received context.Context
+	err := RegisterTransient[Driver](di, func(ctx context.Context) Driver {
+		received = ctx
+		return NewRegularDriver()
+	})
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	_, err = GetContext[Car](ctx, di)
+	this.So(err, should.BeNil)
+	this.So(received.Value(contextKey{}), should.Equal, "request")
+
+	err = CallContext(ctx, di, func(called context.Context, driver Driver) {
+		this.So(called.Value(contextKey{}), should.Equal, "request")
+	})
+	this.So(err, should.BeNil)
+
+	_, err = Get[Car](di)
+	this.So(err, should.BeNil)
+	this.So(received, should.Equal, context.Background())
+}
+
+func (this *InjectorFixture) TestGetContext_StopsBetweenConstructorsWhenCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	di := New()
+	constructed := make([]string, 0)
+	err := RegisterTransient[Driver](di, func() Driver {
+		constructed = append(constructed, "driver")
+		cancel()
+		return NewRegularDriver()
+	})
+	this.So(err, should.BeNil)
+	err = RegisterTransient[Car](di, func(driver Driver) Car {
+		constructed = append(constructed, "car")
+		return NewRegularCar(driver)
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	_, err = GetContext[Car](ctx, di)
+	this.So(err, should.Wrap, context.Canceled)
+	this.So(constructed, should.Equal, []string{"driver"})
+
+	constructed = constructed[:0]
+	err = CallContext(ctx, di, func(car Car) {})
+	this.So(err, should.Wrap, context.Canceled)
+	this.So(err.Error(), should.ContainSubstring, "test.Car -> test.Driver")
+	this.So(constructed, should.BeEmpty)
+}
+
+func (this *InjectorFixture) TestFromContext_SharesTheScopeOfTheContext() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	type contextKey struct{}
+	ctx, closeScope := WithScope(context.Background(), di)
+	defer closeScope()
+	first := skipError(FromContext[Counter](ctx))
+	this.So(first, should.NotBeNil)
+	this.So(skipError(FromContext[Counter](context.WithValue(ctx, contextKey{}, "value"))), should.PointTo, first)
+
+	other, closeOther := di.WithScope(context.Background())
+	defer closeOther()
+	this.So(skipError(FromContext[Counter](other)), should.NotPointTo, first)
+
+	_, err = FromContext[Counter](context.Background())
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
+func (this *InjectorFixture) TestFromContext_ResolvesFromWithinAScopedConstructor() {
+	di := New()
+	err := RegisterScope[Counter](di, NewCallCounter)
+	this.So(err, should.BeNil)
+	err = RegisterScopeError[Driver](di, func(ctx context.Context) (Driver, error) {
+		counter, err := FromContext[Counter](ctx)
+		counter.CallMe()
+		return NewRegularDriver(), err
+	})
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	ctx, closeScope := WithScope(context.Background(), di)
+	defer closeScope()
+	resolved := make(chan error, 1)
+	go func() {
+		_, err := FromContext[Driver](ctx)
+		resolved <- err
+	}()
+
+	select {
+	case err = <-resolved:
+		this.So(err, should.BeNil)
+	case <-time.After(time.Second):
+		this.Error("resolving through the scope from a scoped constructor deadlocked")
+		return
+	}
+
+	this.So(skipError(FromContext[Counter](ctx)).GetCount(), should.Equal, 1)
+}
+
+func (this *InjectorFixture) TestWithScope_ClosesTheScopeOnlyWhenAsked() {
+	di := New()
+	err := RegisterScope[*SignalingCloser](di, NewSignalingCloser)
+	this.So(err, should.BeNil)
+	this.So(Verify(di), should.BeNil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, closeScope := WithScope(ctx, di)
+	closer := skipError(FromContext[*SignalingCloser](ctx))
+	cancel()
+
+	scope, found := ScopeFromContext(ctx)
+	this.So(found, should.BeTrue)
+	this.So(skipError(GetScoped[*SignalingCloser](scope)), should.Equal, closer)
+	select {
+	case <-closer.Closed:
+		this.Error("scope was closed when its context was done")
+	default:
+	}
+
+	this.So(closeScope(), should.BeNil)
+	select {
+	case <-closer.Closed:
+	default:
+		this.Error("scope was not closed by its close function")
+	}
+
+	_, err = GetScoped[*SignalingCloser](scope)
+	this.So(err, should.Wrap, ErrorBadState)
+}
+
 func skipError[T any](value T, err error) T {
 	return value
 }
@@ -0,0 +1,57 @@
+// Package injectortest helps tests substitute fakes in a production injector.
+package injectortest
+
+import (
+	"testing"
+
+	"github.com/smarty/injector"
+)
+
+// Replace swaps the registration of T for the constructor until the test ends
+// and verifies the injector. The original registration is restored, and the
+// injector verified again, when the test and its subtests complete. The test
+// fails immediately if the replacement or the verification fails.
+//
+// Parameters:
+//   - t is the test the replacement is scoped to.
+//   - target is the Injector T was registered in.
+//   - lifecycle is the lifecycle of the replacement.
+//   - constructor is the requisite function to generate T.
+//   - options customize the replacement.
+func Replace[T any](t testing.TB, target *injector.Injector, lifecycle injector.Lifecycle, constructor any, options ...injector.RegistrationOption) {
+	t.Helper()
+	restore, err := injector.Replace[T](target, lifecycle, constructor, options...)
+	scope(t, target, restore, err)
+}
+
+// ReplaceInstance swaps the registration of T for the instance until the test
+// ends and verifies the injector, see Replace.
+//
+// Parameters:
+//   - t is the test the replacement is scoped to.
+//   - target is the Injector T was registered in.
+//   - instance is the value returned for T during the test.
+//   - options customize the replacement.
+func ReplaceInstance[T any](t testing.TB, target *injector.Injector, instance T, options ...injector.RegistrationOption) {
+	t.Helper()
+	restore, err := injector.ReplaceInstance[T](target, instance, options...)
+	scope(t, target, restore, err)
+}
+
+func scope(t testing.TB, target *injector.Injector, restore func(), err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("replacing registration: %v", err)
+	}
+
+	t.Cleanup(func() {
+		restore()
+		if err := injector.Verify(target); err != nil {
+			t.Errorf("verifying injector after restoring registration: %v", err)
+		}
+	})
+
+	if err = injector.Verify(target); err != nil {
+		t.Fatalf("verifying injector after replacing registration: %v", err)
+	}
+}
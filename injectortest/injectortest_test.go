@@ -0,0 +1,61 @@
+package injectortest
+
+import (
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+	"github.com/smarty/injector"
+	. "github.com/smarty/injector/internal/test"
+)
+
+func TestInjectortestFixture(t *testing.T) {
+	gunit.Run(new(InjectortestFixture), t)
+}
+
+type InjectortestFixture struct {
+	*gunit.Fixture
+}
+
+func (this *InjectortestFixture) Setup() {
+}
+
+func (this *InjectortestFixture) TestReplaceInstance_RestoredOnCleanup() {
+	di := injector.New()
+	err := injector.RegisterSingleton[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	err = injector.RegisterTransient[Car](di, NewRegularCar)
+	this.So(err, should.BeNil)
+	this.So(injector.Verify(di), should.BeNil)
+
+	this.Run("replaced", func(fixture *gunit.Fixture) {
+		ReplaceInstance[Driver](fixture.T().(testing.TB), di, NewLoopDriver(nil))
+
+		car, err := injector.Get[Car](di)
+		fixture.So(err, should.BeNil)
+		fixture.So(car.GetDriver().GetName(), should.Equal, "Lupin")
+	})
+
+	car, err := injector.Get[Car](di)
+	this.So(err, should.BeNil)
+	this.So(car.GetDriver().GetName(), should.Equal, "Norman")
+}
+
+func (this *InjectortestFixture) TestReplace_RestoredOnCleanup() {
+	di := injector.New()
+	err := injector.RegisterTransient[Driver](di, NewRegularDriver)
+	this.So(err, should.BeNil)
+	this.So(injector.Verify(di), should.BeNil)
+
+	this.Run("replaced", func(fixture *gunit.Fixture) {
+		Replace[Driver](fixture.T().(testing.TB), di, injector.SingletonLifecycle, func() Driver { return NewLoopDriver(nil) })
+
+		driver, err := injector.Get[Driver](di)
+		fixture.So(err, should.BeNil)
+		fixture.So(driver.GetName(), should.Equal, "Lupin")
+	})
+
+	driver, err := injector.Get[Driver](di)
+	this.So(err, should.BeNil)
+	this.So(driver.GetName(), should.Equal, "Norman")
+}
@@ -2,6 +2,5 @@ package contracts
 
 import "reflect"
 
-type KeyType reflect.Type
 type ConstructorType reflect.Type
 type ConstructorValue reflect.Value
@@ -0,0 +1,10 @@
+package contracts
+
+type CaptivePolicy byte
+
+const (
+	CaptiveDefault CaptivePolicy = iota
+	CaptiveError
+	CaptiveStrict
+	CaptiveWarning
+)
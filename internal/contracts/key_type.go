@@ -0,0 +1,46 @@
+package contracts
+
+import "reflect"
+
+type KeyType struct {
+	Type      reflect.Type
+	Qualifier string
+	Member    string
+}
+
+func NewKey(keyType reflect.Type, qualifier string) KeyType {
+	return KeyType{Type: keyType, Qualifier: qualifier}
+}
+
+// NewMemberKey is the key of a group member or map entry. Members are kept
+// apart from the qualifiers users register under, as no qualifier can
+// produce a key with a member.
+func NewMemberKey(keyType reflect.Type, member string) KeyType {
+	return KeyType{Type: keyType, Member: member}
+}
+
+// Name is the short type name of the key, followed by the qualifier in square
+// brackets or the member in curly brackets when one is present.
+func (this KeyType) Name() string {
+	switch {
+	case this.Member != "":
+		return this.Type.Name() + "{" + this.Member + "}"
+	case this.Qualifier != "":
+		return this.Type.Name() + "[" + this.Qualifier + "]"
+	default:
+		return this.Type.Name()
+	}
+}
+
+// String is the full type name of the key, followed by the qualifier in square
+// brackets or the member in curly brackets when one is present.
+func (this KeyType) String() string {
+	switch {
+	case this.Member != "":
+		return this.Type.String() + "{" + this.Member + "}"
+	case this.Qualifier != "":
+		return this.Type.String() + "[" + this.Qualifier + "]"
+	default:
+		return this.Type.String()
+	}
+}
@@ -7,3 +7,16 @@ const (
 	Scope
 	Singleton
 )
+
+func (this Lifecycle) String() string {
+	switch this {
+	case Transient:
+		return "transient"
+	case Scope:
+		return "scope"
+	case Singleton:
+		return "singleton"
+	default:
+		return "unknown"
+	}
+}
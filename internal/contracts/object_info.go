@@ -1,10 +1,30 @@
 package contracts
 
+import (
+	"context"
+	"sync"
+	"time"
+)
+
 type ObjectInfo struct {
 	ConstructorType         ConstructorType
 	ConstructorValue        ConstructorValue
+	Dependencies            []KeyType
+	Optional                []bool
+	Deferred                []bool
+	MapKeys                 []string
+	Name                    string
 	Lifecycle               Lifecycle
 	Singleton               any
-	ConstructorFunction     func(*[]ScopedInstance) (value any, err error)
+	Mutex                   sync.Mutex
+	ConstructorFunction     func(ctx context.Context, scoped *ScopedInstances) (value any, err error)
 	ConstructorReturnsError bool
+	OnStart                 func(ctx context.Context, instance any) error
+	OnStop                  func(ctx context.Context, instance any) error
+	HookTimeout             time.Duration
+	Stopped                 bool
+	Eager                   bool
+	CaptivePolicy           CaptivePolicy
+	Container               bool
+	External                bool
 }
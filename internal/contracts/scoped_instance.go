@@ -1,6 +1,74 @@
 package contracts
 
+import "sync"
+
 type ScopedInstance struct {
 	Type  KeyType
+	Info  *ObjectInfo
 	Value any
 }
+
+// ScopedInstances holds the scoped instances shared by one resolution, or by
+// every resolution made through one scope. The mutex is only held while the
+// list is read or appended to, never while an instance is constructed, so a
+// constructor may resolve through the same list.
+type ScopedInstances struct {
+	// Owner is the explicit scope the list belongs to, or nil for the list of
+	// a single resolution.
+	Owner     any
+	mutex     sync.Mutex
+	instances []ScopedInstance
+}
+
+// Find returns the value of the scoped instance of the registration.
+func (this *ScopedInstances) Find(info *ObjectInfo) (value any, found bool) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	for _, instance := range this.instances {
+		if instance.Info == info {
+			return instance.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// Add appends the instance and returns the value to use for its
+// registration: the instance's own value, or the value of an instance of the
+// same registration added while it was being constructed. Both are kept, so
+// that both are disposed of.
+func (this *ScopedInstances) Add(instance ScopedInstance) (value any) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	value = instance.Value
+	for _, existing := range this.instances {
+		if existing.Info == instance.Info {
+			value = existing.Value
+			break
+		}
+	}
+
+	this.instances = append(this.instances, instance)
+	return value
+}
+
+// Drain empties the list and returns its instances, in creation order.
+func (this *ScopedInstances) Drain() []ScopedInstance {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	instances := this.instances
+	this.instances = nil
+	return instances
+}
+
+// Reset empties the list, keeping its capacity for reuse.
+func (this *ScopedInstances) Reset() {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	clear(this.instances)
+	this.instances = this.instances[:0]
+}
@@ -74,6 +74,32 @@ func (this *BubbleList[Tkey, Tvalue]) Find(key Tkey, reorder ReorderOption) (val
 	return value, found
 }
 
+// Replace swaps the value mapped to an existing key.
+//
+// Guaranteed to be thread-safe.
+//
+// Parameters:
+//   - key is the search value used to find the payload value.
+//   - value is the payload that replaces the current one.
+//
+// Returns:
+//   - previous is the payload value that was replaced.
+//   - found indicates if the key was present. Nothing is inserted when it
+//     was not.
+func (this *BubbleList[Tkey, Tvalue]) Replace(key Tkey, value Tvalue) (previous Tvalue, found bool) {
+	defer this.Unlock()
+	this.Lock()
+	for i := range this.entries {
+		if this.entries[i].key == key {
+			previous = this.entries[i].value
+			this.entries[i].value = value
+			return previous, true
+		}
+	}
+
+	return previous, false
+}
+
 // Prepare is called right before Verify. Any preparation before search
 // functions is done here.
 func (this *BubbleList[Tkey, Tvalue]) Prepare() {}
@@ -34,6 +34,20 @@ type Cache[Tkey comparable, Tvalue any] interface {
 	//   - found indicates if a value was found or not.
 	Find(key Tkey, reorder ReorderOption) (value Tvalue, found bool)
 
+	// Replace swaps the value mapped to an existing key.
+	//
+	// Guaranteed to be thread-safe.
+	//
+	// Parameters:
+	//   - key is the search value used to find the payload value.
+	//   - value is the payload that replaces the current one.
+	//
+	// Returns:
+	//   - previous is the payload value that was replaced.
+	//   - found indicates if the key was present. Nothing is inserted when it
+	//     was not.
+	Replace(key Tkey, value Tvalue) (previous Tvalue, found bool)
+
 	// Prepare is called right before Verify. Any preparation before search
 	// functions is done here.
 	Prepare()
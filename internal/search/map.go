@@ -3,18 +3,23 @@ package search
 import (
 	"iter"
 	"maps"
+	"sync"
+	"sync/atomic"
 )
 
-// Map wraps a basic Go map instance.
+// Map wraps a basic Go map instance. Replace swaps in a copy of the map, so
+// that Find never has to lock.
 type Map[Tkey comparable, Tvalue any] struct {
-	entries map[Tkey]Tvalue
+	replacing sync.Mutex
+	entries   atomic.Pointer[map[Tkey]Tvalue]
 }
 
 // NewMap generates a new map cache.
 func NewMap[Tkey comparable, Tvalue any]() *Map[Tkey, Tvalue] {
-	return &Map[Tkey, Tvalue]{
-		entries: make(map[Tkey]Tvalue),
-	}
+	cache := &Map[Tkey, Tvalue]{}
+	entries := make(map[Tkey]Tvalue)
+	cache.entries.Store(&entries)
+	return cache
 }
 
 // Add inserts the key-value pair into this cache.
@@ -26,14 +31,14 @@ func NewMap[Tkey comparable, Tvalue any]() *Map[Tkey, Tvalue] {
 //   - key maps the payload value.
 //   - value is the payload that is mapped to key.
 func (this *Map[Tkey, Tvalue]) Add(key Tkey, value Tvalue) {
-	this.entries[key] = value
+	(*this.entries.Load())[key] = value
 }
 
 // All iterates through all key-value pairs.
 //
 // All is only called during Verify, when Find calls don't reorder.
 func (this *Map[Tkey, Tvalue]) All() iter.Seq2[Tkey, Tvalue] {
-	return maps.All(this.entries)
+	return maps.All(*this.entries.Load())
 }
 
 // Find searches the cache and returns the found value (if any)
@@ -49,10 +54,38 @@ func (this *Map[Tkey, Tvalue]) All() iter.Seq2[Tkey, Tvalue] {
 //   - value is the payload value found from key.
 //   - found indicates if a value was found or not.
 func (this *Map[Tkey, Tvalue]) Find(key Tkey, reorder ReorderOption) (value Tvalue, found bool) {
-	v, ok := this.entries[key]
+	v, ok := (*this.entries.Load())[key]
 	return v, ok
 }
 
+// Replace swaps the value mapped to an existing key.
+//
+// Guaranteed to be thread-safe.
+//
+// Parameters:
+//   - key is the search value used to find the payload value.
+//   - value is the payload that replaces the current one.
+//
+// Returns:
+//   - previous is the payload value that was replaced.
+//   - found indicates if the key was present. Nothing is inserted when it
+//     was not.
+func (this *Map[Tkey, Tvalue]) Replace(key Tkey, value Tvalue) (previous Tvalue, found bool) {
+	this.replacing.Lock()
+	defer this.replacing.Unlock()
+
+	current := *this.entries.Load()
+	previous, found = current[key]
+	if !found {
+		return previous, false
+	}
+
+	replaced := maps.Clone(current)
+	replaced[key] = value
+	this.entries.Store(&replaced)
+	return previous, true
+}
+
 // Prepare is called right before Verify. Any preparation before search
 // functions is done here.
 func (this *Map[Tkey, Tvalue]) Prepare() {}
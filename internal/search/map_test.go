@@ -0,0 +1,62 @@
+package search
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/smarty/assertions/should"
+	"github.com/smarty/gunit"
+)
+
+func TestMapFixture(t *testing.T) {
+	gunit.Run(new(MapFixture), t)
+}
+
+type MapFixture struct {
+	*gunit.Fixture
+}
+
+func (this *MapFixture) Setup() {
+}
+
+func (this *MapFixture) TestReplace() {
+	cache := NewMap[int, string]()
+	cache.Add(1, "one")
+
+	previous, found := cache.Replace(1, "uno")
+	this.So(found, should.BeTrue)
+	this.So(previous, should.Equal, "one")
+	this.So(skipFound(cache.Find(1, NoReorder)), should.Equal, "uno")
+
+	_, found = cache.Replace(2, "dos")
+	this.So(found, should.BeFalse)
+	_, found = cache.Find(2, NoReorder)
+	this.So(found, should.BeFalse)
+}
+
+func (this *MapFixture) TestReplace_ConcurrentWithFind() {
+	cache := NewMap[int, int]()
+	cache.Add(1, 1)
+
+	waiter := sync.WaitGroup{}
+	for range 8 {
+		waiter.Add(1)
+		go func() {
+			defer waiter.Done()
+			for range 100 {
+				_, _ = cache.Find(1, NoReorder)
+			}
+		}()
+	}
+
+	for value := range 100 {
+		cache.Replace(1, value)
+	}
+
+	waiter.Wait()
+	this.So(skipFound(cache.Find(1, NoReorder)), should.Equal, 99)
+}
+
+func skipFound[T any](value T, found bool) T {
+	return value
+}
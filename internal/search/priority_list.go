@@ -90,6 +90,32 @@ func (this *PriorityList[Tkey, Tvalue]) Find(key Tkey, reorder ReorderOption) (v
 	return value, found
 }
 
+// Replace swaps the value mapped to an existing key.
+//
+// Guaranteed to be thread-safe.
+//
+// Parameters:
+//   - key is the search value used to find the payload value.
+//   - value is the payload that replaces the current one.
+//
+// Returns:
+//   - previous is the payload value that was replaced.
+//   - found indicates if the key was present. Nothing is inserted when it
+//     was not.
+func (this *PriorityList[Tkey, Tvalue]) Replace(key Tkey, value Tvalue) (previous Tvalue, found bool) {
+	defer this.Unlock()
+	this.Lock()
+	for current := this.head; current != nil; current = current.next {
+		if current.key == key {
+			previous = current.value
+			current.value = value
+			return previous, true
+		}
+	}
+
+	return previous, false
+}
+
 // Prepare is called right before Verify. Any preparation before search
 // functions is done here.
 func (this *PriorityList[Tkey, Tvalue]) Prepare() {}
@@ -9,11 +9,13 @@ import (
 // StackPool is used for pooling the scoped stacks.
 type StackPool struct {
 	mutex  sync.Mutex
-	stacks [][]contracts.ScopedInstance
+	stacks []*contracts.ScopedInstances
 }
 
-// CheckIn returns the scoped stack back to this pool.
-func (this *StackPool) CheckIn(value []contracts.ScopedInstance) {
+// CheckIn empties the scoped stack and returns it back to this pool.
+func (this *StackPool) CheckIn(value *contracts.ScopedInstances) {
+	value.Reset()
+
 	this.mutex.Lock()
 	defer this.mutex.Unlock()
 
@@ -21,14 +23,12 @@ func (this *StackPool) CheckIn(value []contracts.ScopedInstance) {
 }
 
 // CheckOut will find or generate a new scoped stack and return it.
-func (this *StackPool) CheckOut() []contracts.ScopedInstance {
-	const startSize = 8
-
+func (this *StackPool) CheckOut() *contracts.ScopedInstances {
 	this.mutex.Lock()
 	defer this.mutex.Unlock()
 
 	if len(this.stacks) == 0 {
-		return make([]contracts.ScopedInstance, 0, startSize)
+		return &contracts.ScopedInstances{}
 	}
 
 	value := this.stacks[len(this.stacks)-1]
@@ -1,5 +1,7 @@
 package test
 
+import "context"
+
 // ----- interfaces
 
 type Car interface {
@@ -22,6 +24,8 @@ type CounterWrapper interface {
 	GetRightCount() int
 }
 
+type DriverFactory func(prefix string) Driver
+
 // ----- structs
 
 type RegularCar struct {
@@ -44,10 +48,51 @@ type CallCounterWrapper struct {
 	right Counter
 }
 
+type SignalingCloser struct {
+	Closed chan struct{}
+}
+
+type RecordingCloser struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+type RecordingService struct {
+	name     string
+	events   *[]string
+	startErr error
+}
+
 type StringProvider struct {
 	Values []string
 }
 
+type PrefixDriver struct {
+	inner  Driver
+	prefix string
+}
+
+type TaggedGarage struct {
+	Driver  Driver  `di:"inject"`
+	Backup  Driver  `di:"inject,name=backup"`
+	Counter Counter `di:"optional"`
+	Label   string
+}
+
+type TaggedDriver struct {
+	Car Car `di:"inject"`
+}
+
+type CounterFixture struct {
+	Left  Counter `di:"inject"`
+	Right Counter `di:"inject"`
+}
+
+type BadlyTagged struct {
+	driver Driver `di:"inject"`
+}
+
 // ----- constructors
 
 func NewRegularCar(driver Driver) Car {
@@ -79,6 +124,33 @@ func NewCallCounterWrapper(left Counter, right Counter) *CallCounterWrapper {
 	}
 }
 
+func NewPrefixDriver(inner Driver, prefix string) Driver {
+	return &PrefixDriver{
+		inner:  inner,
+		prefix: prefix,
+	}
+}
+
+func NewSignalingCloser() *SignalingCloser {
+	return &SignalingCloser{Closed: make(chan struct{})}
+}
+
+func NewRecordingCloser(name string, closed *[]string, err error) *RecordingCloser {
+	return &RecordingCloser{
+		name:   name,
+		closed: closed,
+		err:    err,
+	}
+}
+
+func NewRecordingService(name string, events *[]string, startErr error) *RecordingService {
+	return &RecordingService{
+		name:     name,
+		events:   events,
+		startErr: startErr,
+	}
+}
+
 func NewStringProvider(strings ...string) *StringProvider {
 	return &StringProvider{
 		Values: strings,
@@ -99,6 +171,34 @@ func (this *LoopDriver) GetName() string {
 	return "Lupin"
 }
 
+func (this *TaggedDriver) GetName() string {
+	return "Tagged"
+}
+
+func (this *PrefixDriver) GetName() string {
+	return this.prefix + this.inner.GetName()
+}
+
+func (this *SignalingCloser) Close() error {
+	close(this.Closed)
+	return nil
+}
+
+func (this *RecordingCloser) Close() error {
+	*this.closed = append(*this.closed, this.name)
+	return this.err
+}
+
+func (this *RecordingService) Start(ctx context.Context) error {
+	*this.events = append(*this.events, "start "+this.name)
+	return this.startErr
+}
+
+func (this *RecordingService) Stop(ctx context.Context) error {
+	*this.events = append(*this.events, "stop "+this.name)
+	return ctx.Err()
+}
+
 func (this *CallCounter) CallMe() {
 	this.count++
 }
@@ -0,0 +1,8 @@
+package injector
+
+import "github.com/smarty/injector/internal/contracts"
+
+// Key identifies a registration: the registered type and, for named
+// registrations, the qualifier it was registered under. Name renders the short
+// form "Driver[replica]" used by GetByName, String the package-qualified form.
+type Key = contracts.KeyType
@@ -0,0 +1,161 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// Lazy is a constructor parameter that defers resolving its type until [Lazy.Get]
+// is first called, then keeps the value. A Lazy parameter does not count as a
+// dependency loop, so two registrations that need each other can be
+// registered when one of them takes the other lazily.
+//
+// Notes:
+//   - Get must not be called from the constructor the Lazy is passed to when
+//     it is part of a loop, as the loop would then be resolved eagerly.
+//   - Scoped types are resolved through the Scope the Lazy was injected
+//     through, and otherwise in a scope of their own, as a Get on the
+//     injector would. Once that Scope is closed, Get returns ErrorBadState.
+//   - Lazy parameters are also supported by Call, RegisterStruct and Inject.
+type Lazy[T any] func() (T, error)
+
+// Get resolves the value on the first call and returns the same value on
+// every later call. A failed resolution is not kept, so the next call tries
+// again.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the Lazy was left unset, for
+//     example by a `di:"optional"` field whose type is not registered.
+//   - the same errors as Get.
+func (this Lazy[T]) Get() (value T, err error) {
+	if this == nil {
+		return value, fmt.Errorf("%w: lazy type '%s' was not injected", ErrorNotRegistered, reflect.TypeFor[T]().String())
+	}
+
+	return this()
+}
+
+func (this Lazy[T]) deferredType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (this Lazy[T]) resolvesOnce() bool {
+	return true
+}
+
+// Provider is a constructor parameter that resolves its type on every call to
+// [Provider.Get], according to the type's lifecycle. Like [Lazy], a Provider
+// parameter does not count as a dependency loop.
+//
+// Notes:
+//   - Get must not be called from the constructor the Provider is passed to
+//     when it is part of a loop, as the loop would then be resolved eagerly.
+//   - Scoped types are resolved through the Scope the Provider was injected
+//     through, and otherwise in a new scope on every call, as a Get on the
+//     injector would. Once that Scope is closed, Get returns ErrorBadState.
+//   - Provider parameters are also supported by Call, RegisterStruct and
+//     Inject.
+type Provider[T any] func() (T, error)
+
+// Get resolves the value: singletons return the same value on every call,
+// while transient and scoped types return a new value.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the Provider was left unset, for
+//     example by a `di:"optional"` field whose type is not registered.
+//   - the same errors as Get.
+func (this Provider[T]) Get() (value T, err error) {
+	if this == nil {
+		return value, fmt.Errorf("%w: provided type '%s' was not injected", ErrorNotRegistered, reflect.TypeFor[T]().String())
+	}
+
+	return this()
+}
+
+func (this Provider[T]) deferredType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (this Provider[T]) resolvesOnce() bool {
+	return false
+}
+
+// deferred is implemented by Lazy and Provider, whose type parameter is only
+// known through reflection when they appear as a parameter.
+type deferred interface {
+	deferredType() reflect.Type
+	resolvesOnce() bool
+}
+
+// deferredValue generates the Lazy or Provider of the given parameter type,
+// resolving the key when it is called: through the scope owning the scoped
+// list, if any, so that its scoped instances are shared and disposed of by
+// that scope, and otherwise through the injector.
+func deferredValue(injector *Injector, parameterType reflect.Type, key contracts.KeyType, scoped *contracts.ScopedInstances) reflect.Value {
+	scope, _ := scoped.Owner.(*Scope)
+	resolveValue := func() []reflect.Value {
+		var rawValue any
+		var err error
+		if scope != nil {
+			rawValue, err = scope.resolve(context.Background(), key)
+		} else {
+			rawValue, err = injector.resolve(context.Background(), key)
+		}
+
+		value := reflect.New(key.Type).Elem()
+		if err != nil {
+			return []reflect.Value{value, reflect.ValueOf(&err).Elem()}
+		}
+
+		if rawValue != nil {
+			value.Set(reflect.ValueOf(rawValue))
+		}
+
+		return []reflect.Value{value, reflect.Zero(reflect.TypeFor[error]())}
+	}
+
+	if !reflect.Zero(parameterType).Interface().(deferred).resolvesOnce() {
+		return reflect.MakeFunc(parameterType, func([]reflect.Value) []reflect.Value {
+			return resolveValue()
+		})
+	}
+
+	var mutex sync.Mutex
+	var resolved []reflect.Value
+	return reflect.MakeFunc(parameterType, func([]reflect.Value) []reflect.Value {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if resolved != nil {
+			return resolved
+		}
+
+		returns := resolveValue()
+		if returns[1].IsNil() {
+			resolved = returns
+		}
+
+		return returns
+	})
+}
+
+// isDeferred reports whether the dependency at the given index is resolved
+// through a Lazy or Provider, rather than when the registration is
+// constructed.
+func isDeferred(info *contracts.ObjectInfo, index int) bool {
+	return index < len(info.Deferred) && info.Deferred[index]
+}
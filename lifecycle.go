@@ -0,0 +1,19 @@
+package injector
+
+import "github.com/smarty/injector/internal/contracts"
+
+// Lifecycle determines how often the constructor of a registration is called.
+type Lifecycle = contracts.Lifecycle
+
+const (
+	// TransientLifecycle calls the constructor every time the type is
+	// requested.
+	TransientLifecycle Lifecycle = contracts.Transient
+
+	// ScopeLifecycle calls the constructor once per Get() or Call() call.
+	ScopeLifecycle Lifecycle = contracts.Scope
+
+	// SingletonLifecycle calls the constructor once for the lifetime of the
+	// injector.
+	SingletonLifecycle Lifecycle = contracts.Singleton
+)
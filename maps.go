@@ -0,0 +1,196 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// RegisterMapEntry adds a constructor as the entry under mapKey of the map of
+// the given type. Any constructor or function declaring a parameter of type
+// map[string]key receives every entry of the map.
+//
+// Notes:
+//   - Each entry keeps its own lifecycle, the map itself is rebuilt every
+//     time it is requested.
+//   - Contributing the same mapKey twice is reported by Verify as
+//     ErrorDuplicateMapKey.
+//   - Individual entries are reachable through GetByName as
+//     "map[string]TypeName[mapKey]".
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use
+//     [Injector.RegisterMapEntryError].
+//
+// Parameters:
+//   - key is the element type of the map.
+//   - mapKey is the key the entry is contributed under.
+//   - lifecycle is the lifecycle of this entry.
+//   - constructor is the requisite function to generate the entry.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingleton], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) RegisterMapEntry(key reflect.Type, mapKey string, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        lifecycle,
+	}
+
+	return registerMapEntry(this, key, mapKey, info, options)
+}
+
+// RegisterMapEntryError adds a constructor as the entry under mapKey of the
+// map of the given type. Any constructor or function declaring a parameter of
+// type map[string]key receives every entry of the map.
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use [Injector.RegisterMapEntry] instead.
+//
+// Parameters:
+//   - key is the element type of the map.
+//   - mapKey is the key the entry is contributed under.
+//   - lifecycle is the lifecycle of this entry.
+//   - constructor is the requisite function to generate the entry.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as [Injector.RegisterSingletonError], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) RegisterMapEntryError(key reflect.Type, mapKey string, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	info := &contracts.ObjectInfo{
+		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:               lifecycle,
+		ConstructorReturnsError: true,
+	}
+
+	return registerMapEntry(this, key, mapKey, info, options)
+}
+
+// RegisterMapEntry adds a constructor as the entry under mapKey of the map of
+// the given type. Any constructor or function declaring a parameter of type
+// map[string]Tkey receives every entry of the map.
+//
+// Notes:
+//   - Each entry keeps its own lifecycle, the map itself is rebuilt every
+//     time it is requested.
+//   - Contributing the same mapKey twice is reported by Verify as
+//     ErrorDuplicateMapKey.
+//   - Individual entries are reachable through GetByName as
+//     "map[string]TypeName[mapKey]".
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use RegisterMapEntryError.
+//
+// Parameters:
+//   - target is the Injector to register the entry in.
+//   - mapKey is the key the entry is contributed under.
+//   - lifecycle is the lifecycle of this entry.
+//   - constructor is the requisite function to generate the entry.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingleton, except that
+//     ErrorAlreadyRegistered is never returned.
+func RegisterMapEntry[Tkey any](target *Injector, mapKey string, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	return target.RegisterMapEntry(reflect.TypeFor[Tkey](), mapKey, lifecycle, constructor, options...)
+}
+
+// RegisterMapEntryError adds a constructor as the entry under mapKey of the
+// map of the given type. Any constructor or function declaring a parameter of
+// type map[string]Tkey receives every entry of the map.
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use RegisterMapEntry instead.
+//
+// Parameters:
+//   - target is the Injector to register the entry in.
+//   - mapKey is the key the entry is contributed under.
+//   - lifecycle is the lifecycle of this entry.
+//   - constructor is the requisite function to generate the entry.
+//   - options customize the registration.
+//
+// Errors:
+//   - the same errors as RegisterSingletonError, except that
+//     ErrorAlreadyRegistered is never returned.
+func RegisterMapEntryError[Tkey any](target *Injector, mapKey string, lifecycle Lifecycle, constructor any, options ...RegistrationOption) error {
+	return target.RegisterMapEntryError(reflect.TypeFor[Tkey](), mapKey, lifecycle, constructor, options...)
+}
+
+// mapConstructor generates a constructor taking one parameter per entry and
+// storing each one under the map key at the same index of mapKeys. Duplicate
+// keys never reach it, as Verify reports them, see verifyMapKeys.
+func mapConstructor(mapType reflect.Type, mapKeys []string) (contracts.ConstructorType, contracts.ConstructorValue) {
+	parameters := make([]reflect.Type, len(mapKeys))
+	for iParameter := range parameters {
+		parameters[iParameter] = mapType.Elem()
+	}
+
+	constructorType := reflect.FuncOf(parameters, []reflect.Type{mapType}, false)
+	constructorValue := reflect.MakeFunc(constructorType, func(entries []reflect.Value) []reflect.Value {
+		mapValue := reflect.MakeMapWithSize(mapType, len(entries))
+		for iEntry, entry := range entries {
+			mapValue.SetMapIndex(reflect.ValueOf(mapKeys[iEntry]), entry)
+		}
+
+		return []reflect.Value{mapValue}
+	})
+
+	return constructorType, contracts.ConstructorValue(constructorValue)
+}
+
+func registerMapEntry(target *Injector, key reflect.Type, mapKey string, info *contracts.ObjectInfo, options []RegistrationOption) error {
+	mapOfKey := contracts.NewKey(reflect.MapOf(reflect.TypeFor[string](), key), "")
+	mapInfo, found := target.library.Find(mapOfKey, search.NoReorder)
+	entryCount := 0
+	if found {
+		entryCount = len(mapInfo.Dependencies)
+	}
+
+	entryKey := contracts.NewMemberKey(key, fmt.Sprintf("map#%d", entryCount))
+	if err := register(target, entryKey, info, options); err != nil {
+		return err
+	}
+
+	if !found {
+		mapInfo = &contracts.ObjectInfo{Lifecycle: contracts.Transient, Container: true}
+		mapInfo.ConstructorFunction = newConstructorFunction(target, mapInfo)
+		target.library.Add(mapOfKey, mapInfo)
+	}
+
+	mapInfo.Dependencies = append(mapInfo.Dependencies, entryKey)
+	mapInfo.MapKeys = append(mapInfo.MapKeys, mapKey)
+	mapInfo.ConstructorType, mapInfo.ConstructorValue = mapConstructor(mapOfKey.Type, mapInfo.MapKeys)
+
+	info.Name = "map[string]" + trieName(contracts.NewKey(key, mapKey))
+	target.nameToKeyTrie.Add(info.Name, entryKey)
+	return nil
+}
+
+func verifyMapKeys(key contracts.KeyType, info *contracts.ObjectInfo) error {
+	seen := make(map[string]struct{}, len(info.MapKeys))
+	var duplicates []string
+	for _, mapKey := range info.MapKeys {
+		if _, ok := seen[mapKey]; ok {
+			duplicates = append(duplicates, mapKey)
+		}
+
+		seen[mapKey] = struct{}{}
+	}
+
+	if len(duplicates) > 0 {
+		return fmt.Errorf(
+			"%w: map of type '%s' has duplicate keys %q",
+			ErrorDuplicateMapKey,
+			key.Type.String(),
+			strings.Join(duplicates, ", "))
+	}
+
+	return nil
+}
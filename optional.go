@@ -0,0 +1,70 @@
+package injector
+
+import (
+	"reflect"
+)
+
+// Optional is a constructor parameter whose type may be left unregistered, so
+// that a feature such as a tracing exporter is only wired when it has been
+// registered. Verify does not report an unregistered optional type, but still
+// verifies the dependencies of the type when it is registered.
+//
+// Notes:
+//   - Optional parameters are also supported by Call, RegisterStruct and
+//     Inject. A `di:"optional"` struct tag has the same effect on a field
+//     of the plain type, which is left at its zero value instead.
+type Optional[T any] struct {
+	value   T
+	present bool
+}
+
+// Get returns the injected value, if the type was registered.
+//
+// Returns:
+//   - value is the injected value, or the zero value when absent.
+//   - present reports whether the type was registered.
+func (this Optional[T]) Get() (value T, present bool) {
+	return this.value, this.present
+}
+
+// Present reports whether the type was registered.
+func (this Optional[T]) Present() bool {
+	return this.present
+}
+
+func (this Optional[T]) optionalType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func (this *Optional[T]) set(value any) {
+	if value != nil {
+		this.value = value.(T)
+	}
+
+	this.present = true
+}
+
+// optional is implemented by Optional, whose type parameter is only known
+// through reflection when it appears as a parameter.
+type optional interface {
+	optionalType() reflect.Type
+}
+
+// settable is implemented by a pointer to an Optional.
+type settable interface {
+	set(value any)
+}
+
+// isOptionalType reports whether the parameter type is an Optional. A pointer
+// to an Optional is a plain type, as its methods cannot be called on a nil
+// pointer.
+func isOptionalType(parameterType reflect.Type) bool {
+	return parameterType.Kind() == reflect.Struct && parameterType.Implements(reflect.TypeFor[optional]())
+}
+
+// presentValue wraps the resolved value in the Optional parameter type.
+func presentValue(parameterType reflect.Type, value reflect.Value) reflect.Value {
+	wrapper := reflect.New(parameterType)
+	wrapper.Interface().(settable).set(value.Interface())
+	return wrapper.Elem()
+}
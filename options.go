@@ -0,0 +1,146 @@
+package injector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// RegistrationOption customizes a single registration. Options are applied
+// after the constructor has been validated, in the order they were passed.
+type RegistrationOption func(info *contracts.ObjectInfo) error
+
+// WithNamedParameter requests that a constructor parameter is satisfied by the
+// registration made under the given qualifier name rather than the unnamed
+// registration of the parameter's type.
+//
+// Parameters:
+//   - index is the zero-based position of the constructor parameter.
+//   - name is the qualifier the parameter's type was registered under.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when index is not a valid parameter
+//     position for the constructor.
+func WithNamedParameter(index int, name string) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if index < 0 || index >= len(info.Dependencies) {
+			return fmt.Errorf(
+				"%w: parameter index [%d] is out of range for a constructor with [%d] parameters",
+				ErrorInvalidOption,
+				index,
+				len(info.Dependencies))
+		}
+
+		info.Dependencies[index].Qualifier = name
+		return nil
+	}
+}
+
+// OnStart attaches a hook that [Injector.Start] calls with the constructed
+// singleton, in dependency order. The hook replaces the Start method of a
+// [Starter] singleton.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the registration is not a singleton
+//     or its constructor does not return a value assignable to T.
+func OnStart[T any](hook func(ctx context.Context, instance T) error) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if err := assertHookable[T](info); err != nil {
+			return err
+		}
+
+		info.OnStart = func(ctx context.Context, instance any) error { return hook(ctx, instance.(T)) }
+		return nil
+	}
+}
+
+// OnStop attaches a hook that [Injector.Stop] calls with the started
+// singleton, in reverse dependency order. The hook replaces the Stop method of
+// a [Stopper] singleton.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the registration is not a singleton
+//     or its constructor does not return a value assignable to T.
+func OnStop[T any](hook func(ctx context.Context, instance T) error) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if err := assertHookable[T](info); err != nil {
+			return err
+		}
+
+		info.OnStop = func(ctx context.Context, instance any) error { return hook(ctx, instance.(T)) }
+		return nil
+	}
+}
+
+// Eager marks a singleton to be constructed by Verify, right after the
+// structural checks succeed, so a failing constructor is discovered at boot.
+// See VerifyAndBuild to construct every singleton instead.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the registration is not a singleton.
+func Eager() RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if info.Lifecycle != contracts.Singleton {
+			return fmt.Errorf("%w: eager construction requires a singleton, got a %s", ErrorInvalidOption, info.Lifecycle)
+		}
+
+		info.Eager = true
+		return nil
+	}
+}
+
+// WithCaptivePolicy overrides the captive policy of the injector, see
+// [Injector.SetCaptivePolicy], for a single singleton registration.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the registration is not a singleton
+//     or the policy is unknown.
+func WithCaptivePolicy(policy CaptivePolicy) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if info.Lifecycle != contracts.Singleton {
+			return fmt.Errorf("%w: a captive policy requires a singleton, got a %s", ErrorInvalidOption, info.Lifecycle)
+		}
+
+		if err := assertCaptivePolicy(policy); err != nil {
+			return err
+		}
+
+		info.CaptivePolicy = policy
+		return nil
+	}
+}
+
+// WithHookTimeout bounds every start and stop hook of the registration,
+// including Start and Stop methods, by the given duration.
+//
+// Errors:
+//   - ErrorInvalidOption is returned when the timeout is not positive.
+func WithHookTimeout(timeout time.Duration) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		if timeout <= 0 {
+			return fmt.Errorf("%w: hook timeout must be positive, got [%s]", ErrorInvalidOption, timeout)
+		}
+
+		info.HookTimeout = timeout
+		return nil
+	}
+}
+
+func assertHookable[T any](info *contracts.ObjectInfo) error {
+	if info.Lifecycle != contracts.Singleton {
+		return fmt.Errorf("%w: start and stop hooks require a singleton, got a %s", ErrorInvalidOption, info.Lifecycle)
+	}
+
+	if hookType := reflect.TypeFor[T](); !info.ConstructorType.Out(0).AssignableTo(hookType) {
+		return fmt.Errorf(
+			"%w: constructor's return type '%s' is not assignable to hook type '%s'",
+			ErrorInvalidOption,
+			info.ConstructorType.Out(0).String(),
+			hookType.String())
+	}
+
+	return nil
+}
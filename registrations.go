@@ -0,0 +1,81 @@
+package injector
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// Registration describes a single registration of an injector.
+type Registration struct {
+	// Key is the registered type and qualifier.
+	Key Key
+
+	// Lifecycle is the lifecycle of the registration.
+	Lifecycle Lifecycle
+
+	// Constructor is the signature of the constructor, including the
+	// parameters of any decorators.
+	Constructor reflect.Type
+
+	// ReturnsError reports whether the constructor also returns an error.
+	ReturnsError bool
+
+	// Dependencies are the keys injected into the constructor, in parameter
+	// order.
+	Dependencies []Key
+
+	// Name is the name the registration is found under by GetByName. It is
+	// empty for the slices and maps assembled from group members and map
+	// entries.
+	Name string
+
+	// Instantiated reports whether the registration is a singleton that has
+	// already been constructed.
+	Instantiated bool
+}
+
+// Registrations lists every registration of the injector, including the
+// injector itself, sorted by key.
+//
+// Notes:
+//   - Registrations inherited from a parent injector are not listed, see
+//     [Injector.NewChild].
+//   - The registrations are captured when iteration starts, so registering
+//     while iterating is safe but not reflected.
+//
+// Returns:
+//   - registrations yields one descriptor per registration.
+func (this *Injector) Registrations() (registrations iter.Seq[Registration]) {
+	return func(yield func(Registration) bool) {
+		for _, key := range sortedKeys(this) {
+			info, found := this.library.Find(key, search.NoReorder)
+			if !found {
+				continue
+			}
+
+			if !yield(describe(key, info)) {
+				return
+			}
+		}
+	}
+}
+
+func describe(key contracts.KeyType, info *contracts.ObjectInfo) Registration {
+	info.Mutex.Lock()
+	instantiated := info.Lifecycle == contracts.Singleton && info.Singleton != nil
+	info.Mutex.Unlock()
+
+	return Registration{
+		Key:          key,
+		Lifecycle:    info.Lifecycle,
+		Constructor:  info.ConstructorType,
+		ReturnsError: info.ConstructorReturnsError,
+		Dependencies: slices.Clone(info.Dependencies),
+		Name:         info.Name,
+		Instantiated: instantiated,
+	}
+}
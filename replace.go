@@ -0,0 +1,262 @@
+package injector
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/smarty/injector/internal/contracts"
+	"github.com/smarty/injector/internal/search"
+)
+
+// Replace swaps the registration of an already registered type for a new
+// constructor, typically to substitute a fake in tests. The injector must be
+// verified again before it is used.
+//
+// Notes:
+//   - Every cached singleton depending on the type, directly or indirectly,
+//     is dropped without being disposed of, so that it is constructed again
+//     with the replacement. Singletons of child injectors are left alone.
+//   - Decorators of the replaced registration do not apply to the
+//     replacement.
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use [Injector.ReplaceError].
+//
+// Parameters:
+//   - key is the registered type to replace.
+//   - lifecycle is the lifecycle of the replacement.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back, again dropping the
+//     cached singletons depending on it. The injector must then be verified
+//     again.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the type has not been registered.
+//   - the same errors as [Injector.RegisterSingleton], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) Replace(key reflect.Type, lifecycle Lifecycle, constructor any, options ...RegistrationOption) (restore func(), err error) {
+	info := &contracts.ObjectInfo{
+		ConstructorType:  contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue: contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:        lifecycle,
+	}
+
+	return replace(this, contracts.NewKey(key, ""), info, options)
+}
+
+// ReplaceError swaps the registration of an already registered type for a
+// new constructor that also returns an error, see [Injector.Replace].
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use [Injector.Replace] instead.
+//
+// Parameters:
+//   - key is the registered type to replace.
+//   - lifecycle is the lifecycle of the replacement.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the type has not been registered.
+//   - the same errors as [Injector.RegisterSingletonError], except that
+//     ErrorAlreadyRegistered is never returned.
+func (this *Injector) ReplaceError(key reflect.Type, lifecycle Lifecycle, constructor any, options ...RegistrationOption) (restore func(), err error) {
+	info := &contracts.ObjectInfo{
+		ConstructorType:         contracts.ConstructorType(reflect.TypeOf(constructor)),
+		ConstructorValue:        contracts.ConstructorValue(reflect.ValueOf(constructor)),
+		Lifecycle:               lifecycle,
+		ConstructorReturnsError: true,
+	}
+
+	return replace(this, contracts.NewKey(key, ""), info, options)
+}
+
+// ReplaceInstance swaps the registration of an already registered type for
+// an already constructed instance, see [Injector.Replace].
+//
+// Parameters:
+//   - key is the registered type to replace.
+//   - instance is the value returned for the type from now on.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back.
+//
+// Errors:
+//   - ErrorNotAssignable is returned when instance is nil or cannot be
+//     assigned to the key type.
+//   - ErrorNotRegistered is returned when the type has not been registered.
+func (this *Injector) ReplaceInstance(key reflect.Type, instance any, options ...RegistrationOption) (restore func(), err error) {
+	if instance == nil {
+		return nil, fmt.Errorf(
+			"%w: nil instance for type '%s'",
+			ErrorNotAssignable,
+			key.Name())
+	}
+
+	return replace(this, contracts.NewKey(key, ""), instanceInfo(instance), options)
+}
+
+// Replace swaps the registration of an already registered type for a new
+// constructor, typically to substitute a fake in tests. The injector must be
+// verified again before it is used.
+//
+// Notes:
+//   - Every cached singleton depending on the type, directly or indirectly,
+//     is dropped without being disposed of, so that it is constructed again
+//     with the replacement. Singletons of child injectors are left alone.
+//   - Decorators of the replaced registration do not apply to the
+//     replacement.
+//   - Constructor is expected to be a function that returns exactly one value.
+//     if the constructor also returns an error, use ReplaceError.
+//
+// Parameters:
+//   - target is the Injector the type was registered in.
+//   - lifecycle is the lifecycle of the replacement.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back, again dropping the
+//     cached singletons depending on it. The injector must then be verified
+//     again.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the type has not been registered.
+//   - the same errors as RegisterSingleton, except that
+//     ErrorAlreadyRegistered is never returned.
+func Replace[Tkey any](target *Injector, lifecycle Lifecycle, constructor any, options ...RegistrationOption) (restore func(), err error) {
+	return target.Replace(reflect.TypeFor[Tkey](), lifecycle, constructor, options...)
+}
+
+// ReplaceError swaps the registration of an already registered type for a
+// new constructor that also returns an error, see Replace.
+//
+// Notes:
+//   - Constructor is expected to return (Tkey, error). If your constructor
+//     does not return an error, use Replace instead.
+//
+// Parameters:
+//   - target is the Injector the type was registered in.
+//   - lifecycle is the lifecycle of the replacement.
+//   - constructor is the requisite function to generate the type.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back.
+//
+// Errors:
+//   - ErrorNotRegistered is returned when the type has not been registered.
+//   - the same errors as RegisterSingletonError, except that
+//     ErrorAlreadyRegistered is never returned.
+func ReplaceError[Tkey any](target *Injector, lifecycle Lifecycle, constructor any, options ...RegistrationOption) (restore func(), err error) {
+	return target.ReplaceError(reflect.TypeFor[Tkey](), lifecycle, constructor, options...)
+}
+
+// ReplaceInstance swaps the registration of an already registered type for
+// an already constructed instance, see Replace.
+//
+// Parameters:
+//   - target is the Injector the type was registered in.
+//   - instance is the value returned for the type from now on.
+//   - options customize the replacement.
+//
+// Returns:
+//   - restore swaps the original registration back.
+//
+// Errors:
+//   - ErrorNotAssignable is returned when instance is nil.
+//   - ErrorNotRegistered is returned when the type has not been registered.
+func ReplaceInstance[Tkey any](target *Injector, instance Tkey, options ...RegistrationOption) (restore func(), err error) {
+	return target.ReplaceInstance(reflect.TypeFor[Tkey](), instance, options...)
+}
+
+// dependents lists every registration depending on the key, directly or
+// indirectly.
+func dependents(injector *Injector, key contracts.KeyType) []*contracts.ObjectInfo {
+	requesters := make(map[contracts.KeyType][]contracts.KeyType)
+	for _, requester := range sortedKeys(injector) {
+		info, _ := injector.library.Find(requester, search.NoReorder)
+		for _, dependency := range info.Dependencies {
+			requesters[dependency] = append(requesters[dependency], requester)
+		}
+	}
+
+	found := make([]*contracts.ObjectInfo, 0)
+	visited := map[contracts.KeyType]struct{}{key: {}}
+	pending := []contracts.KeyType{key}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		for _, requester := range requesters[current] {
+			if _, ok := visited[requester]; ok {
+				continue
+			}
+
+			visited[requester] = struct{}{}
+			info, _ := injector.library.Find(requester, search.NoReorder)
+			found = append(found, info)
+			pending = append(pending, requester)
+		}
+	}
+
+	return found
+}
+
+// dropSingletons forgets the cached instances of the singletons, without
+// disposing of them, so that they are constructed again on their next use.
+func dropSingletons(injector *Injector, infos []*contracts.ObjectInfo) {
+	for _, info := range infos {
+		if info.Lifecycle != contracts.Singleton {
+			continue
+		}
+
+		info.Mutex.Lock()
+		info.Singleton = nil
+		info.Stopped = false
+		info.Mutex.Unlock()
+
+		injector.singletonsMutex.Lock()
+		injector.singletons = slices.DeleteFunc(injector.singletons, func(singleton *contracts.ObjectInfo) bool {
+			return singleton == info
+		})
+		injector.singletonsMutex.Unlock()
+	}
+}
+
+func replace(target *Injector, key contracts.KeyType, info *contracts.ObjectInfo, options []RegistrationOption) (restore func(), err error) {
+	if err = validateConstructor(key, info); err != nil {
+		return nil, err
+	}
+
+	if _, found := target.library.Find(key, search.NoReorder); !found {
+		return nil, fmt.Errorf(
+			"%w: replaced type '%s' must be registered before it is replaced",
+			ErrorNotRegistered,
+			key.Name())
+	}
+
+	if err = applyOptions(target, key, info, options); err != nil {
+		return nil, err
+	}
+
+	original := swap(target, key, info)
+	return func() { swap(target, key, original) }, nil
+}
+
+// swap puts the registration in place of the current registration of the key
+// and returns the registration it replaced.
+func swap(target *Injector, key contracts.KeyType, info *contracts.ObjectInfo) (previous *contracts.ObjectInfo) {
+	target.verified.Store(false)
+	previous, _ = target.library.Replace(key, info)
+	info.Name = previous.Name
+	dropSingletons(target, dependents(target, key))
+	return previous
+}
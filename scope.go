@@ -0,0 +1,295 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// Scope shares the instances of Scope registrations across every Get and Call
+// made through it, until it is closed. A typical scope lives as long as a
+// single HTTP request.
+type Scope struct {
+	injector  *Injector
+	mutex     sync.Mutex
+	instances contracts.ScopedInstances
+	resolving sync.WaitGroup
+	closed    bool
+}
+
+// NewScope creates a new scope whose scoped instances live until
+// [Scope.Close] is called.
+//
+// Returns:
+//   - Scope that resolves through this injector.
+func (this *Injector) NewScope() *Scope {
+	scope := &Scope{injector: this}
+	scope.instances.Owner = scope
+	return scope
+}
+
+// WithScope creates a new scope and returns a copy of ctx carrying it, so that
+// code deep in the handling of a request can resolve through the request's
+// scope with FromContext without the scope being passed along.
+//
+// Notes:
+//   - The scope is not closed when ctx is done, as the context of a request
+//     is also done when its client disconnects, while its handler may still
+//     be using the scoped instances. Like the cancel function of
+//     context.WithCancel, closeScope is typically deferred by the handler.
+//
+// Parameters:
+//   - ctx is the context of the request the scope lives as long as.
+//
+// Returns:
+//   - scoped is a copy of ctx carrying the new scope.
+//   - closeScope closes the scope, see [Scope.Close].
+func (this *Injector) WithScope(ctx context.Context) (scoped context.Context, closeScope func() error) {
+	scope := this.NewScope()
+	return context.WithValue(ctx, scopeContextKey{}, scope), scope.Close
+}
+
+// Call checks a function's signature then calls the function by injecting all
+// the arguments, reusing the scoped instances of this scope. Call is used for
+// any function that has no return values.
+//
+// Parameters:
+//   - function is the function to be called with injected arguments.
+//
+// Returns:
+//   - err returns any error encountered during the call.
+//
+// Errors:
+//   - ErrorBadState is returned if the scope has been closed.
+//   - if calling Get on any of the argument types would error.
+//   - if the function provided is not a function.
+//   - if the function provided is variadic.
+//   - if the function provided has an incongruent number of return values.
+func (this *Scope) Call(function any) (err error) {
+	_, err = this.callN(context.Background(), function, 0)
+	return err
+}
+
+// CallN checks a function's signature then calls the function by injecting all
+// the arguments, reusing the scoped instances of this scope. CallN is used for
+// any function with any number of return values.
+//
+// Parameters:
+//   - function is the function to be called with injected arguments.
+//
+// Returns:
+//   - returns contains all return values.
+//   - err returns any error encountered during the call.
+//
+// Errors:
+//   - ErrorBadState is returned if the scope has been closed.
+//   - if calling Get on any of the argument types would error.
+//   - if the function provided is not a function.
+//   - if the function provided is variadic.
+func (this *Scope) CallN(function any) (returns []any, err error) {
+	return this.callN(context.Background(), function, reflect.TypeOf(function).NumOut())
+}
+
+// Close disposes of every scoped instance created through this scope that
+// implements io.Closer, in reverse creation order. A closed scope cannot be
+// used again, closing it a second time does nothing.
+//
+// Notes:
+//   - Close waits for the resolutions in progress through this scope, so that
+//     the instances they create are disposed of as well. It must therefore
+//     not be called from a constructor resolved through this scope.
+//
+// Returns:
+//   - err joins every error returned by the closed instances.
+func (this *Scope) Close() (err error) {
+	this.mutex.Lock()
+	if this.closed {
+		this.mutex.Unlock()
+		return nil
+	}
+
+	this.closed = true
+	this.mutex.Unlock()
+
+	this.resolving.Wait()
+	instances := this.instances.Drain()
+	for iInstance := len(instances) - 1; iInstance >= 0; iInstance-- {
+		err = errors.Join(err, closeInstance(instances[iInstance].Value))
+	}
+
+	return err
+}
+
+// Get retrieves the given type using the registered constructor or instance,
+// reusing the scoped instances of this scope.
+//
+// Parameters:
+//   - key is the type to look for a registered instance or constructor for.
+//
+// Returns:
+//   - The registered instance or the result of the registered constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorBadState is returned if the scope has been closed.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func (this *Scope) Get(key reflect.Type) (value any, err error) {
+	return this.resolve(context.Background(), contracts.NewKey(key, ""))
+}
+
+// GetNamed retrieves the given type registered under the given qualifier name,
+// reusing the scoped instances of this scope.
+//
+// Parameters:
+//   - key is the type to look for a registered instance or constructor for.
+//   - name is the qualifier the type was registered under.
+//
+// Returns:
+//   - The registered instance or the result of the registered constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorBadState is returned if the scope has been closed.
+//   - ErrorNotRegistered is returned if no registration exists for the type
+//     under the given name.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func (this *Scope) GetNamed(key reflect.Type, name string) (value any, err error) {
+	return this.resolve(context.Background(), contracts.NewKey(key, name))
+}
+
+// GetScoped retrieves the given type using the registered constructor or
+// instance, reusing the scoped instances of the scope.
+//
+// Parameters:
+//   - scope is the scope to get the instance from.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorBadState is returned if the scope has been closed.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func GetScoped[Tkey any](scope *Scope) (value Tkey, err error) {
+	var rawValue any
+	rawValue, err = scope.Get(reflect.TypeFor[Tkey]())
+	if err != nil {
+		return value, err
+	}
+
+	return rawValue.(Tkey), nil
+}
+
+// FromContext retrieves the given type through the scope carried by ctx,
+// sharing scoped instances with every other resolution made through that
+// context. Every context.Context parameter of the constructors run to resolve
+// the type receives ctx, as with GetContext.
+//
+// Parameters:
+//   - ctx is a context returned by WithScope, or derived from one.
+//
+// Returns:
+//   - value is the registered instance or the result of the registered
+//     constructor.
+//   - err is nil unless an error occurred during retrieval.
+//
+// Errors:
+//   - ErrorBadState is returned if ctx carries no scope, or if the scope has
+//     been closed.
+//   - ctx.Err(), wrapped with the resolution path, when ctx is done before a
+//     constructor is invoked.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func FromContext[Tkey any](ctx context.Context) (value Tkey, err error) {
+	scope, found := ScopeFromContext(ctx)
+	if !found {
+		return value, fmt.Errorf("%w: context carries no scope, see WithScope", ErrorBadState)
+	}
+
+	var rawValue any
+	rawValue, err = scope.resolve(ctx, contracts.NewKey(reflect.TypeFor[Tkey](), ""))
+	if err != nil {
+		return value, err
+	}
+
+	return rawValue.(Tkey), nil
+}
+
+// ScopeFromContext returns the scope carried by ctx.
+//
+// Parameters:
+//   - ctx is a context returned by WithScope, or derived from one.
+//
+// Returns:
+//   - scope is the scope carried by ctx.
+//   - found is false when ctx carries no scope.
+func ScopeFromContext(ctx context.Context) (scope *Scope, found bool) {
+	scope, found = ctx.Value(scopeContextKey{}).(*Scope)
+	return scope, found
+}
+
+// WithScope creates a new scope and returns a copy of ctx carrying it, so that
+// code deep in the handling of a request can resolve through the request's
+// scope with FromContext without the scope being passed along.
+//
+// Notes:
+//   - The scope is not closed when ctx is done, as the context of a request
+//     is also done when its client disconnects, while its handler may still
+//     be using the scoped instances. Like the cancel function of
+//     context.WithCancel, closeScope is typically deferred by the handler.
+//
+// Parameters:
+//   - ctx is the context of the request the scope lives as long as.
+//   - injector is the Injector the scope resolves through.
+//
+// Returns:
+//   - scoped is a copy of ctx carrying the new scope.
+//   - closeScope closes the scope, see [Scope.Close].
+func WithScope(ctx context.Context, injector *Injector) (scoped context.Context, closeScope func() error) {
+	return injector.WithScope(ctx)
+}
+
+// scopeContextKey is the context key of the scope carried by a context.
+type scopeContextKey struct{}
+
+// begin counts a resolution in progress, which Close waits for. The mutex is
+// only held while checking the scope, so that a constructor resolving through
+// the same scope, for example with FromContext, does not wait on the
+// resolution it is part of.
+func (this *Scope) begin() error {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+
+	if this.closed {
+		return fmt.Errorf("%w: scope has already been closed", ErrorBadState)
+	}
+
+	this.resolving.Add(1)
+	return nil
+}
+
+func (this *Scope) callN(ctx context.Context, function any, expectedReturnCount int) (returns []any, err error) {
+	if err = this.begin(); err != nil {
+		return nil, err
+	}
+
+	defer this.resolving.Done()
+	return call(ctx, this.injector, function, expectedReturnCount, &this.instances)
+}
+
+func (this *Scope) resolve(ctx context.Context, key contracts.KeyType) (value any, err error) {
+	if err = this.begin(); err != nil {
+		return nil, err
+	}
+
+	defer this.resolving.Done()
+	return resolve(ctx, this.injector, key, &this.instances)
+}
@@ -0,0 +1,313 @@
+package injector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/smarty/injector/internal/contracts"
+)
+
+// Inject populates the tagged fields of a struct that was constructed
+// elsewhere, such as a handler created by a router or a test fixture. Every
+// exported field tagged `di:"inject"` is resolved through the injector, with
+// the same tag forms as [Injector.RegisterStruct].
+//
+// Notes:
+//   - Every field is resolved through one shared scope, which lives only as
+//     long as the call, so two fields of a scoped type receive one instance.
+//   - The fields are only set when every field could be resolved.
+//   - Untagged fields are left untouched.
+//
+// Parameters:
+//   - target is a pointer to the struct to populate.
+//
+// Returns:
+//   - err joins the error of every field that could not be resolved, each
+//     naming its field.
+//
+// Errors:
+//   - ErrorInvalidTag is returned when a tag is malformed or attached to an
+//     unexported field.
+//   - ErrorNotStructOrInterface is returned when target is not a non-nil
+//     pointer to a struct.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func (this *Injector) Inject(target any) error {
+	scopedStack := this.scopePool.CheckOut()
+	defer this.scopePool.CheckIn(scopedStack)
+
+	return inject(context.Background(), this, target, scopedStack)
+}
+
+// RegisterStruct registers a struct type, or a pointer to a struct type,
+// constructed by populating its tagged fields rather than by calling a
+// constructor. Every exported field tagged `di:"inject"` is resolved like a
+// constructor parameter, so tagged fields take part in Verify, loop detection
+// and lifecycles exactly as parameters do.
+//
+// Notes:
+//   - `di:"inject,name=replica"` resolves the field from the registration
+//     made under the qualifier name "replica".
+//   - `di:"optional"` (or `di:"optional,name=replica"`) leaves the field at
+//     its zero value when its type is not registered.
+//   - Untagged fields are left at their zero value.
+//
+// Parameters:
+//   - key is the struct type, or pointer to struct type, to register.
+//   - lifecycle is the lifecycle of the registration.
+//   - options customize the registration. The fields are the parameters,
+//     in declaration order, for WithNamedParameter.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when a type has already been
+//     registered.
+//   - ErrorInvalidTag is returned when a tag is malformed or attached to an
+//     unexported field.
+//   - ErrorNotStructOrInterface is returned when the key type is neither a
+//     struct nor a pointer to a struct.
+func (this *Injector) RegisterStruct(key reflect.Type, lifecycle Lifecycle, options ...RegistrationOption) error {
+	constructorType, constructorValue, fields, err := structConstructor(key)
+	if err != nil {
+		return err
+	}
+
+	info := &contracts.ObjectInfo{
+		ConstructorType:  constructorType,
+		ConstructorValue: constructorValue,
+		Lifecycle:        lifecycle,
+	}
+
+	return register(this, contracts.NewKey(key, ""), info, append([]RegistrationOption{withFields(fields)}, options...))
+}
+
+// Inject populates the tagged fields of a struct that was constructed
+// elsewhere, such as a handler created by a router or a test fixture. Every
+// exported field tagged `di:"inject"` is resolved through the injector, with
+// the same tag forms as RegisterStruct.
+//
+// Notes:
+//   - Every field is resolved through one shared scope, which lives only as
+//     long as the call, so two fields of a scoped type receive one instance.
+//   - The fields are only set when every field could be resolved.
+//   - Untagged fields are left untouched.
+//
+// Parameters:
+//   - injector is the Injector to resolve the fields from.
+//   - target is a pointer to the struct to populate.
+//
+// Returns:
+//   - err joins the error of every field that could not be resolved, each
+//     naming its field.
+//
+// Errors:
+//   - ErrorInvalidTag is returned when a tag is malformed or attached to an
+//     unexported field.
+//   - ErrorNotStructOrInterface is returned when target is not a non-nil
+//     pointer to a struct.
+//   - if Verify() has not been called.
+//   - if Verify() returned an error.
+func Inject(injector *Injector, target any) error {
+	return injector.Inject(target)
+}
+
+// RegisterStruct registers a struct type, or a pointer to a struct type,
+// constructed by populating its tagged fields rather than by calling a
+// constructor. Every exported field tagged `di:"inject"` is resolved like a
+// constructor parameter, so tagged fields take part in Verify, loop detection
+// and lifecycles exactly as parameters do.
+//
+// Notes:
+//   - `di:"inject,name=replica"` resolves the field from the registration
+//     made under the qualifier name "replica".
+//   - `di:"optional"` (or `di:"optional,name=replica"`) leaves the field at
+//     its zero value when its type is not registered.
+//   - Untagged fields are left at their zero value.
+//
+// Parameters:
+//   - target is the Injector to register the type in.
+//   - lifecycle is the lifecycle of the registration.
+//   - options customize the registration. The fields are the parameters,
+//     in declaration order, for WithNamedParameter.
+//
+// Errors:
+//   - ErrorAlreadyRegistered is returned when a type has already been
+//     registered.
+//   - ErrorInvalidTag is returned when a tag is malformed or attached to an
+//     unexported field.
+//   - ErrorNotStructOrInterface is returned when Tkey is neither a struct nor
+//     a pointer to a struct.
+func RegisterStruct[Tkey any](target *Injector, lifecycle Lifecycle, options ...RegistrationOption) error {
+	return target.RegisterStruct(reflect.TypeFor[Tkey](), lifecycle, options...)
+}
+
+// taggedField is an exported struct field carrying a `di` tag.
+type taggedField struct {
+	field    reflect.StructField
+	key      contracts.KeyType
+	optional bool
+	deferred bool
+}
+
+// inject resolves every tagged field of the target before setting any of
+// them, so a failed call leaves the target as it was.
+func inject(ctx context.Context, injector *Injector, target any, scoped *contracts.ScopedInstances) (err error) {
+	if err = assertValidState(injector); err != nil {
+		return err
+	}
+
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf(
+			"%w: injection target of type '%T' must be a non-nil pointer to a struct",
+			ErrorNotStructOrInterface,
+			target)
+	}
+
+	structValue := targetValue.Elem()
+	fields, err := taggedFields(structValue.Type())
+	if err != nil {
+		return err
+	}
+
+	values := make([]reflect.Value, len(fields))
+	for iField, field := range fields {
+		value, e := parameterValue(ctx, injector, field.field.Type, field.key, field.deferred, field.optional, scoped)
+		if e != nil {
+			err = errors.Join(err, fmt.Errorf("field '%s.%s': %w", structValue.Type().String(), field.field.Name, e))
+			continue
+		}
+
+		values[iField] = value
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for iField, field := range fields {
+		structValue.FieldByIndex(field.field.Index).Set(values[iField])
+	}
+
+	return nil
+}
+
+// parseTag reads a `di` tag of the form "inject" or "optional", optionally
+// followed by ",name=qualifier".
+func parseTag(tag string) (qualifier string, optional bool, err error) {
+	parts := strings.Split(tag, ",")
+	switch parts[0] {
+	case "inject":
+	case "optional":
+		optional = true
+	default:
+		return "", false, fmt.Errorf("unknown tag %q, expected \"inject\" or \"optional\"", parts[0])
+	}
+
+	for _, part := range parts[1:] {
+		name, found := strings.CutPrefix(part, "name=")
+		if !found {
+			return "", false, fmt.Errorf("unknown tag option %q, expected \"name=qualifier\"", part)
+		}
+
+		qualifier = name
+	}
+
+	return qualifier, optional, nil
+}
+
+// structConstructor generates a constructor taking one parameter per tagged
+// field, in declaration order, and setting each of them on a new struct,
+// returned by pointer when the key is a pointer type. The tagged fields are
+// returned along with it for withFields.
+func structConstructor(key reflect.Type) (contracts.ConstructorType, contracts.ConstructorValue, []taggedField, error) {
+	structType := key
+	if key.Kind() == reflect.Pointer {
+		structType = key.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil, contracts.ConstructorValue{}, nil, fmt.Errorf(
+			"%w: type '%s' must be a struct or a pointer to a struct",
+			ErrorNotStructOrInterface,
+			key.String())
+	}
+
+	fields, err := taggedFields(structType)
+	if err != nil {
+		return nil, contracts.ConstructorValue{}, nil, err
+	}
+
+	parameters := make([]reflect.Type, len(fields))
+	for iField, field := range fields {
+		parameters[iField] = field.field.Type
+	}
+
+	constructorType := reflect.FuncOf(parameters, []reflect.Type{key}, false)
+	constructorValue := reflect.MakeFunc(constructorType, func(arguments []reflect.Value) []reflect.Value {
+		instance := reflect.New(structType)
+		for iField, field := range fields {
+			instance.Elem().FieldByIndex(field.field.Index).Set(arguments[iField])
+		}
+
+		if key.Kind() == reflect.Pointer {
+			return []reflect.Value{instance}
+		}
+
+		return []reflect.Value{instance.Elem()}
+	})
+
+	return constructorType, contracts.ConstructorValue(constructorValue), fields, nil
+}
+
+// taggedFields lists the fields of the struct type carrying a `di` tag, in
+// declaration order.
+func taggedFields(structType reflect.Type) ([]taggedField, error) {
+	fields := make([]taggedField, 0)
+	for iField := range structType.NumField() {
+		field := structType.Field(iField)
+		tag, tagged := field.Tag.Lookup("di")
+		if !tagged {
+			continue
+		}
+
+		if !field.IsExported() {
+			return nil, fmt.Errorf(
+				"%w: field '%s.%s' is not exported",
+				ErrorInvalidTag,
+				structType.String(),
+				field.Name)
+		}
+
+		qualifier, optional, err := parseTag(tag)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: field '%s.%s': %s",
+				ErrorInvalidTag,
+				structType.String(),
+				field.Name,
+				err)
+		}
+
+		key, isDeferred, isOptional := dependencyKey(field.Type, qualifier)
+		fields = append(fields, taggedField{field: field, key: key, optional: optional || isOptional, deferred: isDeferred})
+	}
+
+	return fields, nil
+}
+
+// withFields applies the qualifiers and optional flags of the tagged fields
+// to the parameters of a struct constructor.
+func withFields(fields []taggedField) RegistrationOption {
+	return func(info *contracts.ObjectInfo) error {
+		info.Optional = make([]bool, len(fields))
+		for iField, field := range fields {
+			info.Dependencies[iField] = field.key
+			info.Optional[iField] = field.optional
+		}
+
+		return nil
+	}
+}